@@ -1,7 +1,11 @@
 package main
 
-import "github.com/kaustuvbot/devopsctl/internal/cli"
+import (
+	"os"
+
+	"github.com/kaustuvbot/devopsctl/internal/cli"
+)
 
 func main() {
-	cli.Execute()
+	os.Exit(cli.Execute())
 }
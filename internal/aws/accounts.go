@@ -0,0 +1,185 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// accountConcurrency bounds how many accounts are audited at once. Each
+// account also fans out across its regions.
+const accountConcurrency = 4
+
+// AccountRun is the audit of one account in aws.accounts. Err is set when
+// the account's role could not be assumed, in which case no checks ran.
+type AccountRun struct {
+	Account  appconfig.AWSAccount
+	Outcomes check.Outcomes
+	Err      error
+}
+
+// AccountRuns holds the audit of every account, in config order.
+type AccountRuns []AccountRun
+
+// RunAccounts audits every account in cfg.Accounts, at most
+// accountConcurrency at a time, with the clients clients.ForAccount returns.
+// The findings and pass records of each account are tagged with its ID and
+// alias.
+func RunAccounts(ctx context.Context, clients *AWSClients, cfg appconfig.AWSConfig, opts check.Options) AccountRuns {
+	runs := make(AccountRuns, len(cfg.Accounts))
+	sem := make(chan struct{}, accountConcurrency)
+	var wg sync.WaitGroup
+	for i, account := range cfg.Accounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			runs[i] = runAccount(ctx, clients.ForAccount(account), account, cfg, opts)
+		}()
+	}
+	wg.Wait()
+	return runs
+}
+
+// runAccount assumes the account's role and runs every check against it.
+func runAccount(ctx context.Context, clients *AWSClients, account appconfig.AWSAccount, cfg appconfig.AWSConfig, opts check.Options) AccountRun {
+	run := AccountRun{Account: account}
+	if err := clients.CheckCredentials(ctx); err != nil {
+		run.Err = fmt.Errorf("cannot assume %s: %w", account.RoleARN, err)
+		return run
+	}
+	run.Outcomes = RunAll(ctx, clients, cfg, opts)
+	for i := range run.Outcomes {
+		o := &run.Outcomes[i]
+		for j := range o.Findings {
+			o.Findings[j].Account = account.ID
+			o.Findings[j].AccountAlias = account.Alias
+		}
+		for j := range o.Passed {
+			o.Passed[j].Account = account.ID
+		}
+	}
+	return run
+}
+
+// Outcomes merges the outcomes of every audited account into one outcome
+// per check. A check errored if it errored in any account, failed if it has
+// findings in any account, and is only skipped when every account skipped
+// it; the accounts that skipped it, or evaluated it in part, are recorded in
+// Partial otherwise. Accounts whose role could not be assumed are left out;
+// Err reports them.
+func (r AccountRuns) Outcomes() check.Outcomes {
+	var merged check.Outcomes
+	index := make(map[string]int)
+	errs := make(map[string][]string)
+	reasons := make(map[string][]string)
+	partial := make(map[string][]string)
+	for _, run := range r {
+		for _, o := range run.Outcomes {
+			i, ok := index[o.ID]
+			if !ok {
+				i = len(merged)
+				index[o.ID] = i
+				merged = append(merged, check.Outcome{ID: o.ID, Status: check.StatusSkipped})
+			}
+			m := &merged[i]
+			m.Findings = append(m.Findings, o.Findings...)
+			m.Passed = append(m.Passed, o.Passed...)
+			m.Evaluated += o.Evaluated
+			if o.Duration > m.Duration {
+				m.Duration = o.Duration
+			}
+			switch o.Status {
+			case check.StatusErrored:
+				errs[o.ID] = append(errs[o.ID], fmt.Sprintf("%s: %v", run.Account.Name(), o.Err))
+			case check.StatusSkipped:
+				reasons[o.ID] = appendUnique(reasons[o.ID], o.SkipReason)
+				partial[o.ID] = append(partial[o.ID], fmt.Sprintf("%s skipped: %s", run.Account.Name(), o.SkipReason))
+			}
+			if o.Partial != "" {
+				partial[o.ID] = append(partial[o.ID], fmt.Sprintf("%s: %s", run.Account.Name(), o.Partial))
+			}
+			if statusRank(o.Status) > statusRank(m.Status) {
+				m.Status = o.Status
+			}
+		}
+	}
+	for i := range merged {
+		m := &merged[i]
+		switch m.Status {
+		case check.StatusErrored:
+			m.Err = errors.New(strings.Join(errs[m.ID], "; "))
+		case check.StatusSkipped:
+			m.SkipReason = strings.Join(reasons[m.ID], ", ")
+		default:
+			m.Partial = strings.Join(partial[m.ID], "; ")
+		}
+	}
+	return merged
+}
+
+// statusRank orders check statuses by how they combine across accounts.
+func statusRank(s check.Status) int {
+	switch s {
+	case check.StatusErrored:
+		return 3
+	case check.StatusFailed:
+		return 2
+	case check.StatusPassed:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// Err returns an error naming every account that could not be audited and
+// every check that errored, or nil.
+func (r AccountRuns) Err() error {
+	var errs []string
+	for _, run := range r {
+		if run.Err != nil {
+			errs = append(errs, fmt.Sprintf("account %s: %v", run.Account.Name(), run.Err))
+		}
+	}
+	if err := r.Outcomes().Err(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Summaries returns the per-account summary of the runs, in config order.
+// Finding counts are left to reporter.CountAccounts, once the report's
+// findings are final.
+func (r AccountRuns) Summaries() []reporter.AccountSummary {
+	if len(r) == 0 {
+		return nil
+	}
+	summaries := make([]reporter.AccountSummary, 0, len(r))
+	for _, run := range r {
+		s := reporter.AccountSummary{ID: run.Account.ID, Alias: run.Account.Alias}
+		if run.Err != nil {
+			s.Error = run.Err.Error()
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries
+}
@@ -0,0 +1,152 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// fakeSTS grants every AssumeRole call except for the roles in deny, and
+// records the calls it receives.
+type fakeSTS struct {
+	mu    sync.Mutex
+	calls []sts.AssumeRoleInput
+	deny  map[string]bool
+}
+
+func (f *fakeSTS) AssumeRole(_ context.Context, in *sts.AssumeRoleInput, _ ...func(*sts.Options)) (*sts.AssumeRoleOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, *in)
+	if f.deny[aws.ToString(in.RoleArn)] {
+		return nil, fmt.Errorf("AccessDenied: not authorized to perform sts:AssumeRole")
+	}
+	return &sts.AssumeRoleOutput{Credentials: &ststypes.Credentials{
+		AccessKeyId:     aws.String("ASIA" + aws.ToString(in.RoleArn)[13:25]),
+		SecretAccessKey: aws.String("secret"),
+		SessionToken:    aws.String("token"),
+		Expiration:      aws.Time(time.Now().Add(time.Hour)),
+	}}, nil
+}
+
+var (
+	prodAccount = appconfig.AWSAccount{
+		ID: "111111111111", Alias: "prod", RoleARN: "arn:aws:iam::111111111111:role/audit", ExternalID: "ext-1",
+	}
+	devAccount = appconfig.AWSAccount{
+		ID: "222222222222", RoleARN: "arn:aws:iam::222222222222:role/audit",
+	}
+)
+
+func TestAssumeRoleConfig(t *testing.T) {
+	fake := &fakeSTS{}
+	cfg := assumeRoleConfig(aws.Config{Region: "us-east-1"}, fake, prodAccount)
+
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.AccessKeyID != "ASIA111111111111" {
+		t.Errorf("AccessKeyID = %q, want the assumed role's key", creds.AccessKeyID)
+	}
+	if len(fake.calls) != 1 {
+		t.Fatalf("expected 1 AssumeRole call, got %d", len(fake.calls))
+	}
+	in := fake.calls[0]
+	if aws.ToString(in.RoleArn) != prodAccount.RoleARN || aws.ToString(in.ExternalId) != "ext-1" ||
+		aws.ToString(in.RoleSessionName) != assumeRoleSession {
+		t.Errorf("AssumeRole input = %+v", in)
+	}
+}
+
+func TestRunAccounts(t *testing.T) {
+	fake := &fakeSTS{deny: map[string]bool{"arn:aws:iam::333333333333:role/audit": true}}
+	denied := appconfig.AWSAccount{ID: "333333333333", Alias: "sandbox", RoleARN: "arn:aws:iam::333333333333:role/audit"}
+	cfg := appconfig.AWSConfig{KeyAgeDays: 90, Accounts: []appconfig.AWSAccount{prodAccount, devAccount, denied}}
+	clients := &AWSClients{
+		ForAccount: func(a appconfig.AWSAccount) *AWSClients {
+			return &AWSClients{
+				IAM: &mockIAMClient{
+					listUsersOutput:      &iam.ListUsersOutput{Users: []iamtypes.User{{UserName: aws.String("admin")}}},
+					listMFADevicesOutput: &iam.ListMFADevicesOutput{},
+				},
+				awsCfg: assumeRoleConfig(aws.Config{Region: "us-east-1"}, fake, a),
+			}
+		},
+	}
+	opts := check.Options{Enabled: func(id string) bool { return id == "iam-mfa-disabled" }}
+
+	runs := RunAccounts(context.Background(), clients, cfg, opts)
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 account runs, got %d", len(runs))
+	}
+	if runs[2].Err == nil || runs[0].Err != nil || runs[1].Err != nil {
+		t.Errorf("expected only the sandbox account to fail, got %v, %v, %v", runs[0].Err, runs[1].Err, runs[2].Err)
+	}
+
+	outcomes := runs.Outcomes()
+	findings := outcomes.Findings()
+	if len(findings) != 2 {
+		t.Fatalf("expected a finding per audited account, got %v", findings)
+	}
+	if f := findings[0]; f.Account != "111111111111" || f.AccountAlias != "prod" || f.AccountName() != "prod" {
+		t.Errorf("first finding = %+v, want it tagged with prod", f)
+	}
+	if f := findings[1]; f.Account != "222222222222" || f.AccountName() != "222222222222" {
+		t.Errorf("second finding = %+v, want it tagged with the dev account ID", f)
+	}
+	if findings[0].Fingerprint("aws") == findings[1].Fingerprint("aws") {
+		t.Error("findings of different accounts must have different fingerprints")
+	}
+	for _, o := range outcomes {
+		if o.ID == "iam-mfa-disabled" && (o.Status != check.StatusFailed || o.Evaluated != 2) {
+			t.Errorf("iam-mfa-disabled = %+v, want failed with 2 users evaluated", o)
+		}
+	}
+
+	err := runs.Err()
+	if err == nil || !strings.Contains(err.Error(), "account sandbox: cannot assume") {
+		t.Errorf("Err() = %v, want it to name the sandbox account", err)
+	}
+
+	summaries := reporter.CountAccounts(runs.Summaries(), findings)
+	if len(summaries) != 3 || summaries[0].High != 1 || summaries[1].Findings != 1 || summaries[2].Error == "" {
+		t.Errorf("summaries = %+v", summaries)
+	}
+}
+
+func TestAccountRuns_MergeStatuses(t *testing.T) {
+	runs := AccountRuns{
+		{Account: prodAccount, Outcomes: check.Outcomes{
+			{ID: "s3-public-bucket", Status: check.StatusSkipped, SkipReason: "AccessDenied on s3:ListAllMyBuckets"},
+			{ID: "sg-ssh-open", Status: check.StatusErrored, Err: fmt.Errorf("throttled")},
+		}},
+		{Account: devAccount, Outcomes: check.Outcomes{
+			{ID: "s3-public-bucket", Status: check.StatusPassed, Evaluated: 3},
+			{ID: "sg-ssh-open", Status: check.StatusPassed},
+		}},
+	}
+
+	merged := runs.Outcomes()
+	if merged[0].Status != check.StatusPassed || merged[0].Evaluated != 3 {
+		t.Errorf("s3-public-bucket = %+v, want passed: it ran in one account", merged[0])
+	}
+	if want := "prod skipped: AccessDenied on s3:ListAllMyBuckets"; merged[0].Partial != want {
+		t.Errorf("s3-public-bucket partial = %q, want %q", merged[0].Partial, want)
+	}
+	if merged[1].Status != check.StatusErrored || !strings.Contains(merged[1].Err.Error(), "prod: throttled") {
+		t.Errorf("sg-ssh-open = %+v, want errored naming prod", merged[1])
+	}
+}
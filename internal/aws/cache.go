@@ -0,0 +1,29 @@
+package aws
+
+import "sync"
+
+// cached holds a value shared by several checks, computed on first use.
+// Unlike sync.Once, only a successful result is kept: an error, such as the
+// cancellation of the context of the check that asked first, is returned to
+// that caller alone and the next caller tries again.
+type cached[T any] struct {
+	mu    sync.Mutex
+	ok    bool
+	value T
+}
+
+// get returns the cached value, or calls fn and caches its result when it
+// succeeds. Concurrent callers wait for the one calling fn.
+func (c *cached[T]) get(fn func() (T, error)) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ok {
+		return c.value, nil
+	}
+	value, err := fn()
+	if err != nil {
+		return value, err
+	}
+	c.value, c.ok = value, true
+	return value, nil
+}
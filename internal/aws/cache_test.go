@@ -0,0 +1,48 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
+)
+
+func TestCached_KeepsOnlySuccess(t *testing.T) {
+	var c cached[int]
+	calls := 0
+	fn := func(err error) func() (int, error) {
+		return func() (int, error) {
+			calls++
+			return 42, err
+		}
+	}
+
+	if _, err := c.get(fn(context.Canceled)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("first get error = %v, want context.Canceled", err)
+	}
+	if v, err := c.get(fn(nil)); err != nil || v != 42 {
+		t.Fatalf("second get = %d, %v, want 42 after the failure was retried", v, err)
+	}
+	if v, err := c.get(fn(errors.New("not called"))); err != nil || v != 42 || calls != 2 {
+		t.Errorf("third get = %d, %v after %d calls, want the cached 42", v, err, calls)
+	}
+}
+
+func TestRegionalEC2_RetriesAfterCancellation(t *testing.T) {
+	mock := &mockEC2Client{describeRegionsErr: context.DeadlineExceeded}
+	clients := &AWSClients{EC2: mock, Regions: appconfig.Regions{appconfig.AllRegions}}
+
+	if _, err := clients.RegionalEC2(context.Background()); err == nil {
+		t.Fatal("expected the first call to fail")
+	}
+	mock.describeRegionsErr = nil
+	mock.describeRegionsOutput = &ec2.DescribeRegionsOutput{Regions: []ec2types.Region{{RegionName: aws.String("eu-west-1")}}}
+	regions, err := clients.RegionalEC2(context.Background())
+	if err != nil || len(regions) != 1 || regions[0].Region != "eu-west-1" {
+		t.Errorf("RegionalEC2() = %+v, %v, want eu-west-1 once the error is gone", regions, err)
+	}
+}
@@ -3,11 +3,16 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sync"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
 )
 
@@ -21,6 +26,9 @@ type IAMClient interface {
 	ListAttachedUserPolicies(ctx context.Context, params *iam.ListAttachedUserPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListAttachedUserPoliciesOutput, error)
 	ListGroupsForUser(ctx context.Context, params *iam.ListGroupsForUserInput, optFns ...func(*iam.Options)) (*iam.ListGroupsForUserOutput, error)
 	ListAttachedGroupPolicies(ctx context.Context, params *iam.ListAttachedGroupPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListAttachedGroupPoliciesOutput, error)
+	GenerateCredentialReport(ctx context.Context, params *iam.GenerateCredentialReportInput, optFns ...func(*iam.Options)) (*iam.GenerateCredentialReportOutput, error)
+	GetCredentialReport(ctx context.Context, params *iam.GetCredentialReportInput, optFns ...func(*iam.Options)) (*iam.GetCredentialReportOutput, error)
+	GetAccountAuthorizationDetails(ctx context.Context, params *iam.GetAccountAuthorizationDetailsInput, optFns ...func(*iam.Options)) (*iam.GetAccountAuthorizationDetailsOutput, error)
 }
 
 // S3Client is the interface for AWS S3 operations used by devopsctl.
@@ -36,13 +44,32 @@ type S3Client interface {
 type EC2Client interface {
 	DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
 	DescribeVolumes(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
+	DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
 }
 
 // AWSClients holds initialized SDK clients satisfying the service interfaces above.
+// IAM and S3 are global services queried once; EC2 is the client of the home
+// region, and regional checks run against every region in Regions.
 type AWSClients struct {
 	IAM IAMClient
 	S3  S3Client
 	EC2 EC2Client
+
+	// Regions lists the regions regional checks scan, or is
+	// config.AllRegions. Empty scans the home region only.
+	Regions appconfig.Regions
+	// NewEC2 returns an EC2 client for a region other than the home region.
+	NewEC2 func(region string) EC2Client
+	// ForAccount returns the clients of an account in aws.accounts, whose
+	// credentials come from assuming the account's role.
+	ForAccount func(account appconfig.AWSAccount) *AWSClients
+
+	awsCfg   aws.Config
+	regional cached[[]RegionalEC2]
+	report   cached[*CredentialReport]
+	details  cached[*AuthorizationDetails]
+	sgMu     sync.Mutex
+	sgScans  map[string]*cached[[]ec2types.SecurityGroup]
 }
 
 // NewAWSClients initializes real AWS SDK clients using the application config.
@@ -58,9 +85,62 @@ func NewAWSClients(cfg appconfig.AWSConfig) (*AWSClients, error) {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
+	clients := newClients(awsCfg, cfg.Regions)
+	stsClient := sts.NewFromConfig(awsCfg)
+	clients.ForAccount = func(account appconfig.AWSAccount) *AWSClients {
+		regions := cfg.Regions
+		if len(account.Regions) > 0 {
+			regions = account.Regions
+		}
+		return newClients(assumeRoleConfig(awsCfg, stsClient, account), regions)
+	}
+	return clients, nil
+}
+
+// newClients returns the SDK clients of awsCfg, scanning regions with the
+// regional checks.
+func newClients(awsCfg aws.Config, regions appconfig.Regions) *AWSClients {
 	return &AWSClients{
 		IAM: iam.NewFromConfig(awsCfg),
 		S3:  s3.NewFromConfig(awsCfg),
 		EC2: ec2.NewFromConfig(awsCfg),
-	}, nil
+
+		Regions: regions,
+		NewEC2: func(region string) EC2Client {
+			return ec2.NewFromConfig(awsCfg, func(o *ec2.Options) { o.Region = region })
+		},
+
+		awsCfg: awsCfg,
+	}
+}
+
+// assumeRoleSession names the STS sessions of devopsctl, so they can be told
+// apart in CloudTrail.
+const assumeRoleSession = "devopsctl"
+
+// assumeRoleConfig returns a copy of awsCfg whose credentials come from
+// assuming the account's role through client. The credentials are cached
+// and refreshed before they expire.
+func assumeRoleConfig(awsCfg aws.Config, client stscreds.AssumeRoleAPIClient, account appconfig.AWSAccount) aws.Config {
+	cfg := awsCfg.Copy()
+	provider := stscreds.NewAssumeRoleProvider(client, account.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = assumeRoleSession
+		if account.ExternalID != "" {
+			o.ExternalID = aws.String(account.ExternalID)
+		}
+	})
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+	return cfg
+}
+
+// CheckCredentials verifies that the default credential chain resolves to
+// usable credentials, without calling any AWS service.
+func (c *AWSClients) CheckCredentials(ctx context.Context) error {
+	if c.awsCfg.Credentials == nil {
+		return fmt.Errorf("no AWS credentials configured")
+	}
+	if _, err := c.awsCfg.Credentials.Retrieve(ctx); err != nil {
+		return fmt.Errorf("no AWS credentials available: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,360 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// rootUser is the user column of the root account's row in the credential
+// report.
+const rootUser = "<root_account>"
+
+// CredentialReport is the parsed IAM credential report: one row per IAM
+// user plus the root account. Ages are measured from GeneratedAt, so a
+// report is evaluated the same way whenever it is read.
+type CredentialReport struct {
+	GeneratedAt time.Time
+	Users       []CredentialReportUser
+}
+
+// CredentialReportUser is a row of the credential report. Timestamps the
+// report gives as N/A or no_information are zero.
+type CredentialReportUser struct {
+	User                string
+	ARN                 string
+	Created             time.Time
+	PasswordEnabled     bool
+	PasswordLastUsed    time.Time
+	PasswordLastChanged time.Time
+	MFAActive           bool
+	AccessKeys          [2]CredentialReportKey
+}
+
+// CredentialReportKey describes one of the two access keys of a user.
+type CredentialReportKey struct {
+	Active      bool
+	LastRotated time.Time
+	LastUsed    time.Time
+}
+
+// IsRoot reports whether the row is the root account's.
+func (u CredentialReportUser) IsRoot() bool { return u.User == rootUser }
+
+// credentialReportPoll is how long to wait between polls while IAM
+// generates the report, and credentialReportAttempts how many polls to make.
+var (
+	credentialReportPoll     = 2 * time.Second
+	credentialReportAttempts = 15
+)
+
+// CredentialReport returns the account's credential report, generated and
+// downloaded on first successful use and shared by the checks built on it.
+func (c *AWSClients) CredentialReport(ctx context.Context) (*CredentialReport, error) {
+	return c.report.get(func() (*CredentialReport, error) {
+		return GetCredentialReport(ctx, c.IAM)
+	})
+}
+
+// GetCredentialReport asks IAM to generate a credential report, waits until
+// it is complete and returns it parsed.
+func GetCredentialReport(ctx context.Context, client IAMClient) (*CredentialReport, error) {
+	for attempt := 1; ; attempt++ {
+		out, err := client.GenerateCredentialReport(ctx, &iam.GenerateCredentialReportInput{})
+		if err != nil {
+			if isPermissionError(err) {
+				return nil, permissionSkip("iam:GenerateCredentialReport", err)
+			}
+			return nil, fmt.Errorf("GenerateCredentialReport: %w", err)
+		}
+		if out.State == iamtypes.ReportStateTypeComplete {
+			break
+		}
+		if attempt == credentialReportAttempts {
+			return nil, fmt.Errorf("credential report is still %s after %d attempts", out.State, attempt)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(credentialReportPoll):
+		}
+	}
+
+	out, err := client.GetCredentialReport(ctx, &iam.GetCredentialReportInput{})
+	if err != nil {
+		if isPermissionError(err) {
+			return nil, permissionSkip("iam:GetCredentialReport", err)
+		}
+		return nil, fmt.Errorf("GetCredentialReport: %w", err)
+	}
+	generatedAt := time.Now()
+	if out.GeneratedTime != nil {
+		generatedAt = *out.GeneratedTime
+	}
+	return ParseCredentialReport(out.Content, generatedAt)
+}
+
+// ParseCredentialReport parses the CSV content of a credential report
+// generated at generatedAt. Columns are looked up by name, so columns added
+// by later versions of the report are ignored.
+func ParseCredentialReport(content []byte, generatedAt time.Time) (*CredentialReport, error) {
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse credential report: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("parse credential report: empty report")
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	for _, name := range []string{"user", "arn", "user_creation_time", "password_enabled", "mfa_active"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("parse credential report: missing column %q", name)
+		}
+	}
+
+	report := &CredentialReport{GeneratedAt: generatedAt}
+	for line, record := range records[1:] {
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+		var err error
+		timestamp := func(name string) time.Time {
+			t, perr := parseReportTime(field(name))
+			if perr != nil && err == nil {
+				err = fmt.Errorf("parse credential report: line %d: %s: %w", line+2, name, perr)
+			}
+			return t
+		}
+
+		u := CredentialReportUser{
+			User:                field("user"),
+			ARN:                 field("arn"),
+			Created:             timestamp("user_creation_time"),
+			PasswordEnabled:     field("password_enabled") == "true",
+			PasswordLastUsed:    timestamp("password_last_used"),
+			PasswordLastChanged: timestamp("password_last_changed"),
+			MFAActive:           field("mfa_active") == "true",
+		}
+		for i := range u.AccessKeys {
+			prefix := fmt.Sprintf("access_key_%d_", i+1)
+			u.AccessKeys[i] = CredentialReportKey{
+				Active:      field(prefix+"active") == "true",
+				LastRotated: timestamp(prefix + "last_rotated"),
+				LastUsed:    timestamp(prefix + "last_used_date"),
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Users = append(report.Users, u)
+	}
+	return report, nil
+}
+
+// parseReportTime parses a credential report timestamp. The placeholders the
+// report uses for missing values parse as the zero time.
+func parseReportTime(s string) (time.Time, error) {
+	switch s {
+	case "", "N/A", "no_information", "not_supported":
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// daysSince returns the whole days between t and the report's generation.
+func (r *CredentialReport) daysSince(t time.Time) int {
+	return int(r.GeneratedAt.Sub(t).Hours() / 24)
+}
+
+// root returns the root account's row, if the report has one.
+func (r *CredentialReport) root() (CredentialReportUser, bool) {
+	for _, u := range r.Users {
+		if u.IsRoot() {
+			return u, true
+		}
+	}
+	return CredentialReportUser{}, false
+}
+
+// CheckRootMFA checks that the root account has MFA enabled.
+// Severity: CRITICAL
+func CheckRootMFA(ctx context.Context, report *CredentialReport) ([]reporter.CheckResult, error) {
+	root, ok := report.root()
+	if !ok {
+		return nil, nil
+	}
+	if root.MFAActive {
+		check.Pass(ctx, "iam-root-mfa-disabled", "root", "The root account has MFA enabled")
+		return nil, nil
+	}
+	return []reporter.CheckResult{{
+		CheckName:      "iam-root-mfa-disabled",
+		Severity:       "CRITICAL",
+		ResourceID:     "root",
+		Message:        "The root account has no MFA device enabled",
+		Recommendation: "Enable a hardware or virtual MFA device for the root account",
+	}}, nil
+}
+
+// CheckRootAccessKeys checks that the root account has no active access keys.
+// Severity: CRITICAL
+func CheckRootAccessKeys(ctx context.Context, report *CredentialReport) ([]reporter.CheckResult, error) {
+	root, ok := report.root()
+	if !ok {
+		return nil, nil
+	}
+	active := 0
+	for _, key := range root.AccessKeys {
+		if key.Active {
+			active++
+		}
+	}
+	if active == 0 {
+		check.Pass(ctx, "iam-root-access-key", "root", "The root account has no active access keys")
+		return nil, nil
+	}
+	return []reporter.CheckResult{{
+		CheckName:      "iam-root-access-key",
+		Severity:       "CRITICAL",
+		ResourceID:     "root",
+		Message:        fmt.Sprintf("The root account has %d active access key(s)", active),
+		Recommendation: "Delete the root account's access keys; use IAM roles or users instead",
+	}}, nil
+}
+
+// CheckRootUsage checks that the root account has not signed in or used an
+// access key in the last usageDays days.
+// Severity: HIGH
+func CheckRootUsage(ctx context.Context, report *CredentialReport, usageDays int) ([]reporter.CheckResult, error) {
+	root, ok := report.root()
+	if !ok {
+		return nil, nil
+	}
+	lastUsed, how := root.PasswordLastUsed, "signed in"
+	for _, key := range root.AccessKeys {
+		if key.LastUsed.After(lastUsed) {
+			lastUsed, how = key.LastUsed, "used an access key"
+		}
+	}
+	if lastUsed.IsZero() || report.daysSince(lastUsed) >= usageDays {
+		check.Pass(ctx, "iam-root-recently-used", "root", fmt.Sprintf("The root account has not been used in the last %d days", usageDays))
+		return nil, nil
+	}
+	return []reporter.CheckResult{{
+		CheckName:      "iam-root-recently-used",
+		Severity:       "HIGH",
+		ResourceID:     "root",
+		Message:        fmt.Sprintf("The root account %s %d days ago", how, report.daysSince(lastUsed)),
+		Recommendation: "Use IAM roles or users for daily work and reserve the root account for tasks that require it",
+	}}, nil
+}
+
+// CheckInactiveConsoleUsers checks for IAM users with a console password who
+// have not signed in for more than inactiveDays days.
+// Severity: MEDIUM
+func CheckInactiveConsoleUsers(ctx context.Context, report *CredentialReport, inactiveDays int) ([]reporter.CheckResult, error) {
+	var results []reporter.CheckResult
+	for _, u := range report.Users {
+		if u.IsRoot() || !u.PasswordEnabled {
+			continue
+		}
+		days := report.daysSince(u.PasswordLastUsed)
+		msg := fmt.Sprintf("IAM user %q last signed in %d days ago", u.User, days)
+		if u.PasswordLastUsed.IsZero() {
+			days = report.daysSince(u.Created)
+			msg = fmt.Sprintf("IAM user %q has not signed in since it was created %d days ago", u.User, days)
+		}
+		if days <= inactiveDays {
+			check.Pass(ctx, "iam-inactive-console-user", u.User, msg)
+			continue
+		}
+		results = append(results, reporter.CheckResult{
+			CheckName:      "iam-inactive-console-user",
+			Severity:       "MEDIUM",
+			ResourceID:     u.User,
+			Message:        msg,
+			Recommendation: "Remove the console password of users who no longer sign in",
+		})
+	}
+	return results, nil
+}
+
+// CheckPasswordAge checks for console passwords older than passwordAgeDays.
+// Severity: MEDIUM
+func CheckPasswordAge(ctx context.Context, report *CredentialReport, passwordAgeDays int) ([]reporter.CheckResult, error) {
+	var results []reporter.CheckResult
+	for _, u := range report.Users {
+		if u.IsRoot() || !u.PasswordEnabled {
+			continue
+		}
+		changed := u.PasswordLastChanged
+		if changed.IsZero() {
+			changed = u.Created
+		}
+		days := report.daysSince(changed)
+		msg := fmt.Sprintf("Password of IAM user %q is %d days old", u.User, days)
+		if days <= passwordAgeDays {
+			check.Pass(ctx, "iam-old-password", u.User, msg)
+			continue
+		}
+		results = append(results, reporter.CheckResult{
+			CheckName:      "iam-old-password",
+			Severity:       "MEDIUM",
+			ResourceID:     u.User,
+			Message:        msg,
+			Recommendation: "Rotate console passwords and enforce a maximum age in the account password policy",
+		})
+	}
+	return results, nil
+}
+
+// CheckUnusedAccessKeys checks for active access keys that were never used
+// or not used in more than inactiveDays days. A key that was never used is
+// reported once it is older than inactiveDays.
+// Severity: MEDIUM
+func CheckUnusedAccessKeys(ctx context.Context, report *CredentialReport, inactiveDays int) ([]reporter.CheckResult, error) {
+	var results []reporter.CheckResult
+	for _, u := range report.Users {
+		if u.IsRoot() {
+			continue
+		}
+		for i, key := range u.AccessKeys {
+			if !key.Active {
+				continue
+			}
+			resource := fmt.Sprintf("%s/access-key-%d", u.User, i+1)
+			days := report.daysSince(key.LastUsed)
+			msg := fmt.Sprintf("Access key %d of %q was last used %d days ago", i+1, u.User, days)
+			if key.LastUsed.IsZero() {
+				days = report.daysSince(key.LastRotated)
+				msg = fmt.Sprintf("Access key %d of %q has never been used since it was created %d days ago", i+1, u.User, days)
+			}
+			if days <= inactiveDays {
+				check.Pass(ctx, "iam-unused-access-key", resource, msg)
+				continue
+			}
+			results = append(results, reporter.CheckResult{
+				CheckName:      "iam-unused-access-key",
+				Severity:       "MEDIUM",
+				ResourceID:     resource,
+				Message:        msg,
+				Recommendation: "Deactivate and delete access keys that are not used",
+			})
+		}
+	}
+	return results, nil
+}
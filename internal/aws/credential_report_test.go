@@ -0,0 +1,152 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// reportGeneratedAt is when testdata/credential_report.csv is evaluated.
+var reportGeneratedAt = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func loadCredentialReport(t *testing.T) *CredentialReport {
+	t.Helper()
+	content, err := os.ReadFile("testdata/credential_report.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := ParseCredentialReport(content, reportGeneratedAt)
+	if err != nil {
+		t.Fatalf("ParseCredentialReport() error = %v", err)
+	}
+	return report
+}
+
+func TestParseCredentialReport(t *testing.T) {
+	report := loadCredentialReport(t)
+	if len(report.Users) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(report.Users))
+	}
+
+	root := report.Users[0]
+	if !root.IsRoot() || root.PasswordEnabled || root.MFAActive || !root.AccessKeys[0].Active || root.AccessKeys[1].Active {
+		t.Errorf("root = %+v", root)
+	}
+	bob := report.Users[2]
+	if bob.User != "bob" || !bob.PasswordEnabled || !bob.PasswordLastUsed.IsZero() || !bob.AccessKeys[0].LastUsed.IsZero() {
+		t.Errorf("bob = %+v, want a console user who never signed in or used his key", bob)
+	}
+	if want := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC); !report.Users[3].AccessKeys[0].LastUsed.Equal(want) {
+		t.Errorf("carol's key 1 last used = %v, want %v", report.Users[3].AccessKeys[0].LastUsed, want)
+	}
+}
+
+func TestParseCredentialReport_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty":          "",
+		"missing column": "user,arn\nalice,arn:aws:iam::123456789012:user/alice\n",
+		"bad timestamp":  "user,arn,user_creation_time,password_enabled,mfa_active\nalice,arn,yesterday,false,false\n",
+	} {
+		if _, err := ParseCredentialReport([]byte(content), reportGeneratedAt); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCredentialReportChecks(t *testing.T) {
+	report := loadCredentialReport(t)
+	tests := []struct {
+		name  string
+		check func(context.Context, *CredentialReport) ([]reporter.CheckResult, error)
+		want  []string
+	}{
+		{"root mfa", CheckRootMFA, []string{"root"}},
+		{"root access keys", CheckRootAccessKeys, []string{"root"}},
+		{"root usage", func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckRootUsage(ctx, r, 30)
+		}, []string{"root"}},
+		{"root usage outside window", func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckRootUsage(ctx, r, 5)
+		}, nil},
+		{"inactive console users", func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckInactiveConsoleUsers(ctx, r, 90)
+		}, []string{"bob"}},
+		{"password age", func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckPasswordAge(ctx, r, 30)
+		}, []string{"alice", "bob"}},
+		{"unused access keys", func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckUnusedAccessKeys(ctx, r, 90)
+		}, []string{"bob/access-key-1", "carol/access-key-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := tt.check(context.Background(), report)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.ResourceID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCredentialReportChecks_Run(t *testing.T) {
+	content, err := os.ReadFile("testdata/credential_report.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockIAMClient{
+		generateCredentialReportOutput: &iam.GenerateCredentialReportOutput{State: iamtypes.ReportStateTypeComplete},
+		getCredentialReportOutput:      &iam.GetCredentialReportOutput{Content: content, GeneratedTime: aws.Time(reportGeneratedAt)},
+	}
+	cfg := appconfig.DefaultConfig().AWS
+	opts := check.Options{IncludePassed: true, Enabled: func(id string) bool {
+		return id == "iam-root-mfa-disabled" || id == "iam-unused-access-key"
+	}}
+
+	outcomes := check.Run(context.Background(), &AWSClients{IAM: mock}, Checks(cfg), opts)
+	if len(outcomes.Findings()) != 3 {
+		t.Errorf("expected 3 findings, got %+v", outcomes.Findings())
+	}
+	for _, o := range outcomes {
+		if o.ID == "iam-unused-access-key" && (o.Evaluated != 4 || len(o.Passed) != 2) {
+			t.Errorf("iam-unused-access-key: evaluated %d, passed %+v", o.Evaluated, o.Passed)
+		}
+	}
+}
+
+func TestGetCredentialReport_PermissionError(t *testing.T) {
+	mock := &mockIAMClient{generateCredentialReportErr: errors.New("AccessDenied: not authorized")}
+	_, err := GetCredentialReport(context.Background(), mock)
+	var skip *check.SkipError
+	if !errors.As(err, &skip) || skip.Reason != "AccessDenied on iam:GenerateCredentialReport" {
+		t.Errorf("err = %v, want a skip naming iam:GenerateCredentialReport", err)
+	}
+}
+
+func TestGetCredentialReport_NotReady(t *testing.T) {
+	defer func(poll time.Duration, attempts int) {
+		credentialReportPoll, credentialReportAttempts = poll, attempts
+	}(credentialReportPoll, credentialReportAttempts)
+	credentialReportPoll, credentialReportAttempts = 0, 3
+
+	mock := &mockIAMClient{generateCredentialReportOutput: &iam.GenerateCredentialReportOutput{State: iamtypes.ReportStateTypeInprogress}}
+	if _, err := GetCredentialReport(context.Background(), mock); err == nil {
+		t.Error("expected an error for a report that never completes")
+	}
+}
@@ -6,12 +6,14 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
-// CheckEBSEncryption checks for unencrypted EBS volumes.
+// CheckEBSEncryption checks for unencrypted EBS volumes in one region.
+// Resource IDs are prefixed with region.
 // Severity: HIGH
-func CheckEBSEncryption(ctx context.Context, client EC2Client) ([]reporter.CheckResult, error) {
+func CheckEBSEncryption(ctx context.Context, client EC2Client, region string) ([]reporter.CheckResult, error) {
 	var results []reporter.CheckResult
 
 	vols, err := describeAllVolumes(ctx, client)
@@ -19,22 +21,26 @@ func CheckEBSEncryption(ctx context.Context, client EC2Client) ([]reporter.Check
 		return nil, err
 	}
 	for _, vol := range vols {
+		resourceID := regionalID(region, *vol.VolumeId)
 		if vol.Encrypted == nil || !*vol.Encrypted {
 			results = append(results, reporter.CheckResult{
 				CheckName:      "ebs-unencrypted",
 				Severity:       "HIGH",
-				ResourceID:     *vol.VolumeId,
+				ResourceID:     resourceID,
 				Message:        fmt.Sprintf("EBS volume %q is not encrypted", *vol.VolumeId),
 				Recommendation: "Enable EBS encryption by default in your AWS account settings",
 			})
+		} else {
+			check.Pass(ctx, "ebs-unencrypted", resourceID, fmt.Sprintf("EBS volume %q is encrypted", *vol.VolumeId))
 		}
 	}
 	return results, nil
 }
 
-// CheckEBSUnattached checks for EBS volumes not attached to any instance.
+// CheckEBSUnattached checks for EBS volumes not attached to any instance in
+// one region. Resource IDs are prefixed with region.
 // Severity: LOW
-func CheckEBSUnattached(ctx context.Context, client EC2Client) ([]reporter.CheckResult, error) {
+func CheckEBSUnattached(ctx context.Context, client EC2Client, region string) ([]reporter.CheckResult, error) {
 	var results []reporter.CheckResult
 
 	vols, err := describeAllVolumes(ctx, client)
@@ -42,14 +48,17 @@ func CheckEBSUnattached(ctx context.Context, client EC2Client) ([]reporter.Check
 		return nil, err
 	}
 	for _, vol := range vols {
+		resourceID := regionalID(region, *vol.VolumeId)
 		if vol.State == ec2types.VolumeStateAvailable {
 			results = append(results, reporter.CheckResult{
 				CheckName:      "ebs-unattached",
 				Severity:       "LOW",
-				ResourceID:     *vol.VolumeId,
+				ResourceID:     resourceID,
 				Message:        fmt.Sprintf("EBS volume %q is not attached to any instance", *vol.VolumeId),
 				Recommendation: "Delete unused EBS volumes to reduce costs",
 			})
+		} else {
+			check.Pass(ctx, "ebs-unattached", resourceID, fmt.Sprintf("EBS volume %q is %s", *vol.VolumeId, vol.State))
 		}
 	}
 	return results, nil
@@ -59,7 +68,7 @@ func describeAllVolumes(ctx context.Context, client EC2Client) ([]ec2types.Volum
 	out, err := client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{})
 	if err != nil {
 		if isPermissionError(err) {
-			return nil, nil
+			return nil, permissionSkip("ec2:DescribeVolumes", err)
 		}
 		return nil, fmt.Errorf("DescribeVolumes: %w", err)
 	}
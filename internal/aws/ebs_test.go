@@ -19,7 +19,7 @@ func TestCheckEBSEncryption_Unencrypted(t *testing.T) {
 			}},
 		},
 	}
-	results, err := CheckEBSEncryption(context.Background(), mock)
+	results, err := CheckEBSEncryption(context.Background(), mock, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -38,7 +38,7 @@ func TestCheckEBSEncryption_Encrypted(t *testing.T) {
 			}},
 		},
 	}
-	results, err := CheckEBSEncryption(context.Background(), mock)
+	results, err := CheckEBSEncryption(context.Background(), mock, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -57,7 +57,7 @@ func TestCheckEBSUnattached_Available(t *testing.T) {
 			}},
 		},
 	}
-	results, err := CheckEBSUnattached(context.Background(), mock)
+	results, err := CheckEBSUnattached(context.Background(), mock, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -76,7 +76,7 @@ func TestCheckEBSUnattached_InUse(t *testing.T) {
 			}},
 		},
 	}
-	results, _ := CheckEBSUnattached(context.Background(), mock)
+	results, _ := CheckEBSUnattached(context.Background(), mock, "")
 	if len(results) != 0 {
 		t.Errorf("expected no results for in-use volume, got %d", len(results))
 	}
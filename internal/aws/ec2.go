@@ -5,28 +5,46 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
-// CheckSecurityGroups checks for overly permissive security group rules.
+// CheckSecurityGroups checks for overly permissive security group rules in
+// one region. Resource IDs are prefixed with region.
 // Severity: CRITICAL for port 22 open to 0.0.0.0/0 or all-traffic rules.
-func CheckSecurityGroups(ctx context.Context, client EC2Client) ([]reporter.CheckResult, error) {
-	var results []reporter.CheckResult
+func CheckSecurityGroups(ctx context.Context, client EC2Client, region string) ([]reporter.CheckResult, error) {
+	groups, err := describeSecurityGroups(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateSecurityGroups(ctx, groups, region), nil
+}
 
-	sgsOut, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{})
+// describeSecurityGroups returns the security groups of the client's region.
+func describeSecurityGroups(ctx context.Context, client EC2Client) ([]ec2types.SecurityGroup, error) {
+	out, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{})
 	if err != nil {
 		if isPermissionError(err) {
-			return results, nil
+			return nil, permissionSkip("ec2:DescribeSecurityGroups", err)
 		}
 		return nil, fmt.Errorf("DescribeSecurityGroups: %w", err)
 	}
+	return out.SecurityGroups, nil
+}
 
-	for _, sg := range sgsOut.SecurityGroups {
+// evaluateSecurityGroups evaluates every rule of groups once for both
+// security group checks.
+func evaluateSecurityGroups(ctx context.Context, groups []ec2types.SecurityGroup, region string) []reporter.CheckResult {
+	var results []reporter.CheckResult
+	for _, sg := range groups {
 		sgID := *sg.GroupId
+		resourceID := regionalID(region, sgID)
 		sgName := ""
 		if sg.GroupName != nil {
 			sgName = *sg.GroupName
 		}
+		allOpen, sshOpen := false, false
 		for _, perm := range sg.IpPermissions {
 			for _, ipRange := range perm.IpRanges {
 				if ipRange.CidrIp == nil || *ipRange.CidrIp != "0.0.0.0/0" {
@@ -37,10 +55,11 @@ func CheckSecurityGroups(ctx context.Context, client EC2Client) ([]reporter.Chec
 					results = append(results, reporter.CheckResult{
 						CheckName:      "sg-all-ports-open",
 						Severity:       "CRITICAL",
-						ResourceID:     sgID,
+						ResourceID:     resourceID,
 						Message:        fmt.Sprintf("Security group %q (%s) allows all traffic from 0.0.0.0/0", sgName, sgID),
 						Recommendation: "Restrict security group rules to specific ports and CIDR ranges",
 					})
+					allOpen = true
 					break
 				}
 				// SSH port 22 open
@@ -49,13 +68,21 @@ func CheckSecurityGroups(ctx context.Context, client EC2Client) ([]reporter.Chec
 					results = append(results, reporter.CheckResult{
 						CheckName:      "sg-ssh-open",
 						Severity:       "CRITICAL",
-						ResourceID:     sgID,
+						ResourceID:     resourceID,
 						Message:        fmt.Sprintf("Security group %q (%s) allows SSH (port 22) from 0.0.0.0/0", sgName, sgID),
 						Recommendation: "Restrict SSH access to known IP ranges or use AWS Systems Manager Session Manager",
 					})
+					sshOpen = true
 				}
 			}
 		}
+		if !allOpen {
+			check.Pass(ctx, "sg-all-ports-open", resourceID, fmt.Sprintf("Security group %q (%s) does not allow all traffic from 0.0.0.0/0", sgName, sgID))
+		}
+		// An all-traffic rule opens SSH too, so it is no evidence for sg-ssh-open.
+		if !sshOpen && !allOpen {
+			check.Pass(ctx, "sg-ssh-open", resourceID, fmt.Sprintf("Security group %q (%s) does not allow SSH from 0.0.0.0/0", sgName, sgID))
+		}
 	}
-	return results, nil
+	return results
 }
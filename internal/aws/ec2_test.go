@@ -3,26 +3,35 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
 )
 
 type mockEC2Client struct {
 	describeSecurityGroupsOutput *ec2.DescribeSecurityGroupsOutput
 	describeSecurityGroupsErr    error
+	describeSecurityGroupsCalls  atomic.Int32
 	describeVolumesOutput        *ec2.DescribeVolumesOutput
 	describeVolumesErr           error
+	describeRegionsOutput        *ec2.DescribeRegionsOutput
+	describeRegionsErr           error
 }
 
 func (m *mockEC2Client) DescribeSecurityGroups(_ context.Context, _ *ec2.DescribeSecurityGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	m.describeSecurityGroupsCalls.Add(1)
 	return m.describeSecurityGroupsOutput, m.describeSecurityGroupsErr
 }
 func (m *mockEC2Client) DescribeVolumes(_ context.Context, _ *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
 	return m.describeVolumesOutput, m.describeVolumesErr
 }
+func (m *mockEC2Client) DescribeRegions(_ context.Context, _ *ec2.DescribeRegionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+	return m.describeRegionsOutput, m.describeRegionsErr
+}
 
 func TestCheckSecurityGroups_SSHOpen(t *testing.T) {
 	fromPort := int32(22)
@@ -43,7 +52,7 @@ func TestCheckSecurityGroups_SSHOpen(t *testing.T) {
 			}},
 		},
 	}
-	results, err := CheckSecurityGroups(context.Background(), mock)
+	results, err := CheckSecurityGroups(context.Background(), mock, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -70,7 +79,7 @@ func TestCheckSecurityGroups_AllTraffic(t *testing.T) {
 			}},
 		},
 	}
-	results, err := CheckSecurityGroups(context.Background(), mock)
+	results, err := CheckSecurityGroups(context.Background(), mock, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -98,7 +107,7 @@ func TestCheckSecurityGroups_NoPublicAccess(t *testing.T) {
 			}},
 		},
 	}
-	results, _ := CheckSecurityGroups(context.Background(), mock)
+	results, _ := CheckSecurityGroups(context.Background(), mock, "")
 	if len(results) != 0 {
 		t.Errorf("expected no results for private CIDR, got %d", len(results))
 	}
@@ -108,12 +117,48 @@ func TestCheckSecurityGroups_PermissionError(t *testing.T) {
 	mock := &mockEC2Client{
 		describeSecurityGroupsErr: fmt.Errorf("AccessDenied: User is not authorized to perform ec2:DescribeSecurityGroups"),
 	}
-	results, err := CheckSecurityGroups(context.Background(), mock)
-	// Permission errors should be handled gracefully, returning empty results
-	if err != nil {
-		t.Errorf("expected no error for permission denied, got %v", err)
-	}
+	results, err := CheckSecurityGroups(context.Background(), mock, "")
+	// Permission errors skip the check instead of reporting it as passed
+	assertSkipped(t, err, "AccessDenied on ec2:DescribeSecurityGroups")
 	if len(results) != 0 {
 		t.Errorf("expected empty results on permission error, got %d", len(results))
 	}
 }
+
+func TestSecurityGroupChecks_Evidence(t *testing.T) {
+	port := int32(22)
+	mock := &mockEC2Client{
+		describeSecurityGroupsOutput: &ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: []ec2types.SecurityGroup{
+				{
+					GroupId: aws.String("sg-open"),
+					IpPermissions: []ec2types.IpPermission{{
+						FromPort:   &port,
+						ToPort:     &port,
+						IpProtocol: aws.String("tcp"),
+						IpRanges:   []ec2types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+					}},
+				},
+				{GroupId: aws.String("sg-closed")},
+			},
+		},
+	}
+	checks := []check.Check[*AWSClients]{
+		check.New("sg-ssh-open", securityGroupCheck("sg-ssh-open")),
+		check.New("sg-all-ports-open", securityGroupCheck("sg-all-ports-open")),
+	}
+
+	outcomes := check.Run(context.Background(), &AWSClients{EC2: mock}, checks, check.Options{IncludePassed: true})
+
+	ssh, all := outcomes[0], outcomes[1]
+	if ssh.Evaluated != 2 || len(ssh.Passed) != 1 || ssh.Passed[0].ResourceID != "sg-closed" {
+		t.Errorf("sg-ssh-open: evaluated %d, passed %+v", ssh.Evaluated, ssh.Passed)
+	}
+	if all.Evaluated != 2 || len(all.Passed) != 2 {
+		t.Errorf("sg-all-ports-open: evaluated %d, passed %+v", all.Evaluated, all.Passed)
+	}
+	// Both checks share one description of the region's security groups.
+	if n := mock.describeSecurityGroupsCalls.Load(); n != 1 {
+		t.Errorf("DescribeSecurityGroups called %d times, want 1", n)
+	}
+}
@@ -1,16 +1,79 @@
 package aws
 
-import "strings"
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kaustuvbot/devopsctl/internal/check"
+)
+
+// permissionCodes are the AWS error codes that mean the caller lacks access.
+var permissionCodes = []string{
+	"AccessDenied",
+	"UnauthorizedOperation",
+	"AuthFailure",
+	"NoCredentialProviders",
+}
 
 // isPermissionError returns true if an AWS API error is authorization-related.
-// Callers skip checks gracefully on these errors rather than propagating them.
+// Callers skip checks on these errors rather than propagating them.
 func isPermissionError(err error) bool {
+	return permissionCode(err) != ""
+}
+
+// permissionCode returns the permission error code in err, or "".
+func permissionCode(err error) string {
 	if err == nil {
-		return false
+		return ""
 	}
 	msg := err.Error()
-	return strings.Contains(msg, "AccessDenied") ||
-		strings.Contains(msg, "UnauthorizedOperation") ||
-		strings.Contains(msg, "AuthFailure") ||
-		strings.Contains(msg, "NoCredentialProviders")
+	for _, code := range permissionCodes {
+		if strings.Contains(msg, code) {
+			return code
+		}
+	}
+	return ""
+}
+
+// permissionSkip marks a check as skipped because action was denied, with a
+// reason such as "AccessDenied on s3:GetBucketAcl".
+func permissionSkip(action string, err error) error {
+	return check.Skip(fmt.Sprintf("%s on %s", permissionCode(err), action))
+}
+
+// denials counts permission errors on a check's per-resource calls. A check
+// denied on every resource evaluated nothing, so it is skipped rather than
+// reported as passed; one denied on some resources records what it missed.
+type denials struct {
+	action string
+	// noun names the resources in the partial note, e.g. "buckets".
+	noun  string
+	count int
+	err   error
+}
+
+func (d *denials) add(err error) {
+	d.count++
+	d.err = err
+}
+
+// addFor records a denial of action, for checks that make several calls per
+// resource. The skip reason and partial note name the last denied action.
+func (d *denials) addFor(action string, err error) {
+	d.action = action
+	d.add(err)
+}
+
+// finish returns a skip error when all of total resources were denied, and
+// otherwise records the denied ones, if any, as not evaluated.
+func (d *denials) finish(ctx context.Context, total int) error {
+	if d.count == 0 {
+		return nil
+	}
+	if d.count == total {
+		return permissionSkip(d.action, d.err)
+	}
+	check.Partial(ctx, fmt.Sprintf("%d of %d %s denied: %s on %s", d.count, total, d.noun, permissionCode(d.err), d.action))
+	return nil
 }
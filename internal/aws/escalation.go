@@ -0,0 +1,395 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// IAMPrincipal is a user or role with the statements of every policy that
+// applies to it: its inline and attached managed policies and, for a user,
+// those of its groups.
+type IAMPrincipal struct {
+	Kind       string // "user" or "role"
+	Name       string
+	ARN        string
+	Statements []PolicyStatement
+	// Trust is the trust policy of a role.
+	Trust PolicyDocument
+}
+
+// ID identifies the principal in findings, e.g. "role/deploy".
+func (p IAMPrincipal) ID() string { return p.Kind + "/" + p.Name }
+
+// Allows reports whether the principal's policies allow action on resource,
+// or on some resource when resource is empty. An explicit Deny that applies
+// to every resource, or to resource, wins. Conditions are not evaluated,
+// and permissions boundaries and SCPs are not taken into account.
+func (p IAMPrincipal) Allows(action, resource string) bool {
+	allowed := false
+	for _, s := range p.Statements {
+		if !s.Matches(action, resource) {
+			continue
+		}
+		if !s.Allows() {
+			if len(s.Condition) == 0 && (resource != "" || s.AllResources()) {
+				return false
+			}
+			continue
+		}
+		allowed = true
+	}
+	return allowed
+}
+
+// IsAdmin reports whether an Allow statement grants the principal "*" or
+// "*:*" on every resource. NotAction grants, such as PowerUserAccess, do not
+// make an administrator. Only an unconditional Deny of every action takes it
+// back: a narrow guardrail, such as a Deny of cloudtrail:StopLogging, leaves
+// the principal an administrator.
+func (p IAMPrincipal) IsAdmin() bool {
+	admin := false
+	for _, s := range p.Statements {
+		if !s.Allows() {
+			if len(s.Condition) == 0 && s.Matches("*:*", "*") {
+				return false
+			}
+			continue
+		}
+		if s.AllResources() && (s.Action.contains("*") || s.Action.contains("*:*")) {
+			admin = true
+		}
+	}
+	return admin
+}
+
+// Principals returns every user and role with the policy statements that
+// apply to it. Service-linked roles are left out.
+func (d *AuthorizationDetails) Principals() ([]IAMPrincipal, error) {
+	managed := make(map[string]PolicyDocument)
+	for _, p := range d.ManagedPolicies {
+		for _, v := range p.PolicyVersionList {
+			if !v.IsDefaultVersion {
+				continue
+			}
+			doc, err := ParsePolicyDocument(aws.ToString(v.Document))
+			if err != nil {
+				return nil, fmt.Errorf("managed policy %s: %w", aws.ToString(p.Arn), err)
+			}
+			managed[aws.ToString(p.Arn)] = doc
+		}
+	}
+	statements := func(inline []iamtypes.PolicyDetail, attached []iamtypes.AttachedPolicy) ([]PolicyStatement, error) {
+		var out []PolicyStatement
+		for _, p := range inline {
+			doc, err := ParsePolicyDocument(aws.ToString(p.PolicyDocument))
+			if err != nil {
+				return nil, fmt.Errorf("inline policy %s: %w", aws.ToString(p.PolicyName), err)
+			}
+			out = append(out, doc.Statement...)
+		}
+		for _, p := range attached {
+			out = append(out, managed[aws.ToString(p.PolicyArn)].Statement...)
+		}
+		return out, nil
+	}
+
+	groups := make(map[string][]PolicyStatement)
+	for _, g := range d.Groups {
+		s, err := statements(g.GroupPolicyList, g.AttachedManagedPolicies)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", aws.ToString(g.GroupName), err)
+		}
+		groups[aws.ToString(g.GroupName)] = s
+	}
+
+	var principals []IAMPrincipal
+	for _, u := range d.Users {
+		s, err := statements(u.UserPolicyList, u.AttachedManagedPolicies)
+		if err != nil {
+			return nil, fmt.Errorf("user %s: %w", aws.ToString(u.UserName), err)
+		}
+		for _, g := range u.GroupList {
+			s = append(s, groups[g]...)
+		}
+		principals = append(principals, IAMPrincipal{
+			Kind: "user", Name: aws.ToString(u.UserName), ARN: aws.ToString(u.Arn), Statements: s,
+		})
+	}
+	for _, r := range d.Roles {
+		if aws.ToString(r.Path) == serviceRolePath {
+			continue
+		}
+		s, err := statements(r.RolePolicyList, r.AttachedManagedPolicies)
+		if err != nil {
+			return nil, fmt.Errorf("role %s: %w", aws.ToString(r.RoleName), err)
+		}
+		trust, err := ParsePolicyDocument(aws.ToString(r.AssumeRolePolicyDocument))
+		if err != nil {
+			return nil, fmt.Errorf("role %s: trust policy: %w", aws.ToString(r.RoleName), err)
+		}
+		principals = append(principals, IAMPrincipal{
+			Kind: "role", Name: aws.ToString(r.RoleName), ARN: aws.ToString(r.Arn), Statements: s, Trust: trust,
+		})
+	}
+	return principals, nil
+}
+
+// escalationRule is a known combination of permissions that lets a
+// principal gain permissions it was not granted.
+type escalationRule struct {
+	// actions must all be allowed, on some resource.
+	actions []string
+	// steps explain how the actions are used, in order.
+	steps []string
+}
+
+// escalationRules lists the privilege-escalation methods detected, after
+// Rhino Security Labs' "AWS IAM Privilege Escalation" research.
+var escalationRules = []escalationRule{
+	{
+		actions: []string{"iam:CreatePolicyVersion"},
+		steps: []string{
+			"create a new version of a customer managed policy attached to it with iam:CreatePolicyVersion and --set-as-default",
+			`write the version to allow "*" on "*"`,
+		},
+	},
+	{
+		actions: []string{"iam:SetDefaultPolicyVersion"},
+		steps: []string{
+			"make an older, more permissive version of one of its policies the default with iam:SetDefaultPolicyVersion",
+		},
+	},
+	{
+		actions: []string{"iam:AttachUserPolicy"},
+		steps:   []string{"attach AdministratorAccess to itself or another user it controls with iam:AttachUserPolicy"},
+	},
+	{
+		actions: []string{"iam:AttachGroupPolicy"},
+		steps:   []string{"attach AdministratorAccess to a group it is a member of with iam:AttachGroupPolicy"},
+	},
+	{
+		actions: []string{"iam:AttachRolePolicy"},
+		steps:   []string{"attach AdministratorAccess to itself or a role it can assume with iam:AttachRolePolicy"},
+	},
+	{
+		actions: []string{"iam:PutUserPolicy"},
+		steps:   []string{`add an inline policy allowing "*" on "*" to itself or another user it controls with iam:PutUserPolicy`},
+	},
+	{
+		actions: []string{"iam:PutGroupPolicy"},
+		steps:   []string{`add an inline policy allowing "*" on "*" to a group it is a member of with iam:PutGroupPolicy`},
+	},
+	{
+		actions: []string{"iam:PutRolePolicy"},
+		steps:   []string{`add an inline policy allowing "*" on "*" to itself or a role it can assume with iam:PutRolePolicy`},
+	},
+	{
+		actions: []string{"iam:AddUserToGroup"},
+		steps:   []string{"add itself to a group with administrative access with iam:AddUserToGroup"},
+	},
+	{
+		actions: []string{"iam:CreateAccessKey"},
+		steps: []string{
+			"create an access key for a more privileged user with iam:CreateAccessKey",
+			"call AWS with that user's permissions using the new key",
+		},
+	},
+	{
+		actions: []string{"iam:CreateLoginProfile"},
+		steps: []string{
+			"set a console password for a more privileged user that has none with iam:CreateLoginProfile",
+			"sign in to the console as that user",
+		},
+	},
+	{
+		actions: []string{"iam:UpdateLoginProfile"},
+		steps: []string{
+			"change the console password of a more privileged user with iam:UpdateLoginProfile",
+			"sign in to the console as that user",
+		},
+	},
+	{
+		actions: []string{"iam:UpdateAssumeRolePolicy", "sts:AssumeRole"},
+		steps: []string{
+			"change the trust policy of a more privileged role to trust itself with iam:UpdateAssumeRolePolicy",
+			"assume the role with sts:AssumeRole",
+		},
+	},
+	{
+		actions: []string{"iam:PassRole", "lambda:CreateFunction", "lambda:InvokeFunction"},
+		steps: []string{
+			"create a Lambda function with a more privileged role with iam:PassRole and lambda:CreateFunction",
+			"invoke it with lambda:InvokeFunction, running its code with the role's permissions",
+		},
+	},
+	{
+		actions: []string{"iam:PassRole", "ec2:RunInstances"},
+		steps: []string{
+			"launch an EC2 instance with the instance profile of a more privileged role with iam:PassRole and ec2:RunInstances",
+			"read the role's credentials from the instance metadata service",
+		},
+	},
+	{
+		actions: []string{"iam:PassRole", "cloudformation:CreateStack"},
+		steps: []string{
+			"create a CloudFormation stack with a more privileged role with iam:PassRole and cloudformation:CreateStack",
+			"let the stack create IAM resources with the role's permissions",
+		},
+	},
+	{
+		actions: []string{"lambda:UpdateFunctionCode"},
+		steps: []string{
+			"replace the code of an existing Lambda function that has a more privileged role with lambda:UpdateFunctionCode",
+			"wait for the function to run, or invoke it, executing the code with the role's permissions",
+		},
+	},
+}
+
+// paths returns the escalation rules the principal's permissions satisfy.
+func (p IAMPrincipal) paths() []escalationRule {
+	var matched []escalationRule
+	for _, rule := range escalationRules {
+		all := true
+		for _, action := range rule.actions {
+			if !p.Allows(action, "") {
+				all = false
+				break
+			}
+		}
+		if all {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// canAssume reports whether from may assume role: the role's trust policy
+// allows from, directly or through its account, and from's policies allow
+// sts:AssumeRole on the role. Trust policy conditions are not evaluated.
+func canAssume(from, role IAMPrincipal) bool {
+	if from.ARN == role.ARN || !from.Allows("sts:AssumeRole", role.ARN) {
+		return false
+	}
+	account := accountOf(from.ARN)
+	for _, s := range role.Trust.Statement {
+		if !s.Allows() || !s.Matches("sts:AssumeRole", "") {
+			continue
+		}
+		for _, principal := range s.Principal["AWS"] {
+			if principal == "*" || principal == from.ARN ||
+				principal == account || principal == "arn:aws:iam::"+account+":root" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// accountOf returns the account ID of an IAM ARN.
+func accountOf(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 5 {
+		return ""
+	}
+	return parts[4]
+}
+
+// assumeChain returns the shortest chain of roles from can assume one after
+// another to reach a role that is an administrator or has an escalation
+// path of its own, or nil.
+func assumeChain(from IAMPrincipal, roles []IAMPrincipal) []IAMPrincipal {
+	type node struct {
+		role  IAMPrincipal
+		chain []IAMPrincipal
+	}
+	visited := map[string]bool{from.ARN: true}
+	queue := []node{{role: from}}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, r := range roles {
+			if visited[r.ARN] || !canAssume(n.role, r) {
+				continue
+			}
+			visited[r.ARN] = true
+			chain := append(append([]IAMPrincipal(nil), n.chain...), r)
+			if r.IsAdmin() || len(r.paths()) > 0 {
+				return chain
+			}
+			queue = append(queue, node{role: r, chain: chain})
+		}
+	}
+	return nil
+}
+
+// CheckPrivilegeEscalation checks every user and role that is not an
+// administrator for combinations of permissions, listed in escalationRules,
+// that let it gain more permissions, and for chains of roles it can assume
+// that lead to an administrator or to such a combination. Each finding
+// explains every path step by step.
+// Severity: HIGH
+func CheckPrivilegeEscalation(ctx context.Context, principals []IAMPrincipal) []reporter.CheckResult {
+	var roles []IAMPrincipal
+	for _, p := range principals {
+		if p.Kind == "role" {
+			roles = append(roles, p)
+		}
+	}
+
+	var results []reporter.CheckResult
+	for _, p := range principals {
+		if p.IsAdmin() {
+			continue
+		}
+		var paths []string
+		for _, rule := range p.paths() {
+			paths = append(paths, describePath(strings.Join(rule.actions, " + "), rule.steps))
+		}
+		if chain := assumeChain(p, roles); chain != nil {
+			var steps, names []string
+			for _, r := range chain {
+				steps = append(steps, fmt.Sprintf("assume role %q with sts:AssumeRole", r.Name))
+				names = append(names, r.Name)
+			}
+			target := chain[len(chain)-1]
+			if target.IsAdmin() {
+				steps = append(steps, fmt.Sprintf("use role %q's full administrative access", target.Name))
+			} else {
+				rule := target.paths()[0]
+				steps = append(steps, rule.steps...)
+			}
+			paths = append(paths, describePath("sts:AssumeRole chain through "+strings.Join(names, " -> "), steps))
+		}
+
+		subject := fmt.Sprintf("%s %q", strings.ToUpper(p.Kind[:1])+p.Kind[1:], p.Name)
+		if len(paths) == 0 {
+			check.Pass(ctx, "iam-privilege-escalation", p.ID(), subject+" has no known privilege-escalation path")
+			continue
+		}
+		sort.Strings(paths)
+		results = append(results, reporter.CheckResult{
+			CheckName:      "iam-privilege-escalation",
+			Severity:       "HIGH",
+			ResourceID:     p.ID(),
+			Message:        fmt.Sprintf("%s can escalate its privileges: %s", subject, strings.Join(paths, "; ")),
+			Recommendation: "Remove the permissions of each path, or restrict them to specific resources or with a permissions boundary",
+		})
+	}
+	return results
+}
+
+// describePath formats a path as "via <name>: (1) step, (2) step".
+func describePath(name string, steps []string) string {
+	numbered := make([]string, len(steps))
+	for i, s := range steps {
+		numbered[i] = fmt.Sprintf("(%d) %s", i+1, s)
+	}
+	return fmt.Sprintf("via %s: %s", name, strings.Join(numbered, ", "))
+}
@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
+)
+
+func testUser(name, statements string, groups ...string) iamtypes.UserDetail {
+	u := iamtypes.UserDetail{
+		UserName:  aws.String(name),
+		Arn:       aws.String("arn:aws:iam::123456789012:user/" + name),
+		GroupList: groups,
+	}
+	if statements != "" {
+		u.UserPolicyList = []iamtypes.PolicyDetail{{PolicyName: aws.String("inline"), PolicyDocument: policyDoc(statements)}}
+	}
+	return u
+}
+
+func testRole(name, statements, trust string) iamtypes.RoleDetail {
+	return iamtypes.RoleDetail{
+		RoleName:                 aws.String(name),
+		Arn:                      aws.String("arn:aws:iam::123456789012:role/" + name),
+		Path:                     aws.String("/"),
+		AssumeRolePolicyDocument: policyDoc(trust),
+		RolePolicyList:           []iamtypes.PolicyDetail{{PolicyName: aws.String("inline"), PolicyDocument: policyDoc(statements)}},
+	}
+}
+
+// escalationDetails has a principal for each kind of path, and principals
+// with none.
+func escalationDetails() *iam.GetAccountAuthorizationDetailsOutput {
+	return &iam.GetAccountAuthorizationDetailsOutput{
+		UserDetailList: []iamtypes.UserDetail{
+			testUser("dev", `[{"Effect":"Allow","Action":["iam:PassRole","lambda:CreateFunction","lambda:InvokeFunction"],"Resource":"*"}]`),
+			testUser("ops", "", "policy-admins"),
+			testUser("reader", `{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}`),
+			testUser("denied", `[{"Effect":"Allow","Action":"iam:AttachUserPolicy","Resource":"*"},{"Effect":"Deny","Action":"iam:Attach*","Resource":"*"}]`),
+			testUser("admin", `{"Effect":"Allow","Action":"*","Resource":"*"}`),
+			testUser("guarded", `[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","Action":"cloudtrail:StopLogging","Resource":"*"}]`),
+			testUser("power", `[{"Effect":"Allow","NotAction":"iam:*","Resource":"*"},{"Effect":"Allow","Action":"iam:PassRole","Resource":"*"}]`),
+			testUser("carol", `{"Effect":"Allow","Action":"sts:AssumeRole","Resource":"*"}`),
+		},
+		GroupDetailList: []iamtypes.GroupDetail{{
+			GroupName:               aws.String("policy-admins"),
+			AttachedManagedPolicies: []iamtypes.AttachedPolicy{{PolicyArn: aws.String("arn:aws:iam::123456789012:policy/versions")}},
+		}},
+		RoleDetailList: []iamtypes.RoleDetail{
+			testRole("ci",
+				`{"Effect":"Allow","Action":"sts:AssumeRole","Resource":"arn:aws:iam::123456789012:role/break-glass"}`,
+				`{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"sts:AssumeRole"}`),
+			testRole("break-glass",
+				`{"Effect":"Allow","Action":"*","Resource":"*"}`,
+				`{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:role/ci"]},"Action":"sts:AssumeRole"}`),
+		},
+		Policies: []iamtypes.ManagedPolicyDetail{{
+			Arn:        aws.String("arn:aws:iam::123456789012:policy/versions"),
+			PolicyName: aws.String("versions"),
+			PolicyVersionList: []iamtypes.PolicyVersion{
+				{IsDefaultVersion: true, Document: policyDoc(`{"Effect":"Allow","Action":"iam:CreatePolicyVersion","Resource":"*"}`)},
+			},
+		}},
+	}
+}
+
+func TestCheckPrivilegeEscalation(t *testing.T) {
+	details, err := GetAuthorizationDetails(context.Background(), &mockIAMClient{
+		authorizationDetailsOutputs: []*iam.GetAccountAuthorizationDetailsOutput{escalationDetails()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	principals, err := details.Principals()
+	if err != nil {
+		t.Fatalf("Principals() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, r := range CheckPrivilegeEscalation(context.Background(), principals) {
+		got[r.ResourceID] = r.Message
+	}
+	want := map[string][]string{
+		"user/dev":   {"via iam:PassRole + lambda:CreateFunction + lambda:InvokeFunction: (1) create a Lambda function", "(2) invoke it"},
+		"user/ops":   {"via iam:CreatePolicyVersion: (1)"},
+		"user/power": {"via iam:PassRole + "},
+		"user/carol": {"via sts:AssumeRole chain through ci -> break-glass", `(1) assume role "ci"`, `(2) assume role "break-glass"`, `(3) use role "break-glass"'s full administrative access`},
+		"role/ci":    {"via sts:AssumeRole chain through break-glass"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("findings = %v, want findings for %d principals", got, len(want))
+	}
+	for id, parts := range want {
+		for _, part := range parts {
+			if !strings.Contains(got[id], part) {
+				t.Errorf("%s: message = %q, want it to contain %q", id, got[id], part)
+			}
+		}
+	}
+}
+
+func TestIAMPrivilegeEscalation_Run(t *testing.T) {
+	mock := &mockIAMClient{authorizationDetailsOutputs: []*iam.GetAccountAuthorizationDetailsOutput{escalationDetails()}}
+	opts := check.Options{IncludePassed: true, Enabled: func(id string) bool { return id == "iam-privilege-escalation" }}
+
+	outcomes := check.Run(context.Background(), &AWSClients{IAM: mock}, Checks(appconfig.DefaultConfig().AWS), opts)
+	for _, o := range outcomes {
+		if o.ID != "iam-privilege-escalation" {
+			continue
+		}
+		// Administrators are neither findings nor passes.
+		if o.Status != check.StatusFailed || o.Evaluated != 7 || len(o.Passed) != 2 {
+			t.Errorf("status %s, evaluated %d, passed %+v", o.Status, o.Evaluated, o.Passed)
+		}
+	}
+}
+
+func TestIAMPrincipal_IsAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements string
+		want       bool
+	}{
+		{"star", `{"Effect":"Allow","Action":"*","Resource":"*"}`, true},
+		{"star colon star", `{"Effect":"Allow","Action":"*:*","Resource":"*"}`, true},
+		{"narrow deny", `[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","Action":"cloudtrail:StopLogging","Resource":"*"}]`, true},
+		{"conditional deny all", `[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","Action":"*","Resource":"*","Condition":{"Bool":{"aws:MultiFactorAuthPresent":"false"}}}]`, true},
+		{"deny all", `[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","Action":"*","Resource":"*"}]`, false},
+		{"not action", `{"Effect":"Allow","NotAction":"iam:*","Resource":"*"}`, false},
+		{"service wildcard", `{"Effect":"Allow","Action":"iam:*","Resource":"*"}`, false},
+		{"scoped resource", `{"Effect":"Allow","Action":"*","Resource":"arn:aws:s3:::logs/*"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParsePolicyDocument(*policyDoc(tt.statements))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := (IAMPrincipal{Statements: doc.Statement}).IsAdmin(); got != tt.want {
+				t.Errorf("IsAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
@@ -18,17 +19,19 @@ func CheckIAMUsersMFA(ctx context.Context, client IAMClient) ([]reporter.CheckRe
 	users, err := paginateUsers(ctx, client)
 	if err != nil {
 		if isPermissionError(err) {
-			return results, nil
+			return nil, permissionSkip("iam:ListUsers", err)
 		}
 		return nil, fmt.Errorf("iam-mfa: %w", err)
 	}
 
+	denied := denials{action: "iam:ListMFADevices", noun: "users"}
 	for _, user := range users {
 		mfaOut, err := client.ListMFADevices(ctx, &iam.ListMFADevicesInput{
 			UserName: user.UserName,
 		})
 		if err != nil {
 			if isPermissionError(err) {
+				denied.add(err)
 				continue
 			}
 			return nil, fmt.Errorf("ListMFADevices for %s: %w", *user.UserName, err)
@@ -41,8 +44,13 @@ func CheckIAMUsersMFA(ctx context.Context, client IAMClient) ([]reporter.CheckRe
 				Message:        fmt.Sprintf("IAM user %q has no MFA device enabled", *user.UserName),
 				Recommendation: "Enable MFA for all IAM users",
 			})
+		} else {
+			check.Pass(ctx, "iam-mfa-disabled", *user.UserName, fmt.Sprintf("IAM user %q has an MFA device enabled", *user.UserName))
 		}
 	}
+	if err := denied.finish(ctx, len(users)); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
@@ -54,11 +62,12 @@ func CheckIAMAccessKeyAge(ctx context.Context, client IAMClient, keyAgeDays int)
 	users, err := paginateUsers(ctx, client)
 	if err != nil {
 		if isPermissionError(err) {
-			return results, nil
+			return nil, permissionSkip("iam:ListUsers", err)
 		}
 		return nil, fmt.Errorf("iam-key-age: %w", err)
 	}
 
+	denied := denials{action: "iam:ListAccessKeys", noun: "users"}
 	now := time.Now()
 	for _, user := range users {
 		keysOut, err := client.ListAccessKeys(ctx, &iam.ListAccessKeysInput{
@@ -66,6 +75,7 @@ func CheckIAMAccessKeyAge(ctx context.Context, client IAMClient, keyAgeDays int)
 		})
 		if err != nil {
 			if isPermissionError(err) {
+				denied.add(err)
 				continue
 			}
 			return nil, fmt.Errorf("ListAccessKeys for %s: %w", *user.UserName, err)
@@ -87,9 +97,14 @@ func CheckIAMAccessKeyAge(ctx context.Context, client IAMClient, keyAgeDays int)
 					Message:        fmt.Sprintf("Access key for %q is %d days old", *user.UserName, ageDays),
 					Recommendation: "Rotate access keys regularly; delete unused keys",
 				})
+			} else {
+				check.Pass(ctx, "iam-old-access-key", *key.AccessKeyId, fmt.Sprintf("Access key for %q is %d days old", *user.UserName, ageDays))
 			}
 		}
 	}
+	if err := denied.finish(ctx, len(users)); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
@@ -103,15 +118,20 @@ func CheckIAMAdminUsers(ctx context.Context, client IAMClient) ([]reporter.Check
 	users, err := paginateUsers(ctx, client)
 	if err != nil {
 		if isPermissionError(err) {
-			return results, nil
+			return nil, permissionSkip("iam:ListUsers", err)
 		}
 		return nil, fmt.Errorf("iam-admin: %w", err)
 	}
 
+	denied := denials{action: "iam:ListAttachedUserPolicies", noun: "users"}
 	for _, user := range users {
-		isAdmin, err := userHasAdminAccess(ctx, client, *user.UserName)
+		isAdmin, action, err := userHasAdminAccess(ctx, client, *user.UserName)
 		if err != nil {
-			continue
+			if isPermissionError(err) {
+				denied.addFor(action, err)
+				continue
+			}
+			return nil, fmt.Errorf("%s for %s: %w", action, *user.UserName, err)
 		}
 		if isAdmin {
 			results = append(results, reporter.CheckResult{
@@ -121,8 +141,13 @@ func CheckIAMAdminUsers(ctx context.Context, client IAMClient) ([]reporter.Check
 				Message:        fmt.Sprintf("IAM user %q has AdministratorAccess policy", *user.UserName),
 				Recommendation: "Apply least-privilege; remove AdministratorAccess from regular users",
 			})
+		} else {
+			check.Pass(ctx, "iam-admin-access", *user.UserName, fmt.Sprintf("IAM user %q has no AdministratorAccess policy", *user.UserName))
 		}
 	}
+	if err := denied.finish(ctx, len(users)); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
@@ -144,15 +169,19 @@ func paginateUsers(ctx context.Context, client IAMClient) ([]iamtypes.User, erro
 	return users, nil
 }
 
-func userHasAdminAccess(ctx context.Context, client IAMClient, userName string) (bool, error) {
+// userHasAdminAccess reports whether AdministratorAccess is attached to the
+// user directly or through one of its groups. On error it also returns the
+// action that failed.
+func userHasAdminAccess(ctx context.Context, client IAMClient, userName string) (bool, string, error) {
 	policiesOut, err := client.ListAttachedUserPolicies(ctx, &iam.ListAttachedUserPoliciesInput{
 		UserName: &userName,
 	})
-	if err == nil {
-		for _, p := range policiesOut.AttachedPolicies {
-			if *p.PolicyArn == adminPolicyARN {
-				return true, nil
-			}
+	if err != nil {
+		return false, "iam:ListAttachedUserPolicies", err
+	}
+	for _, p := range policiesOut.AttachedPolicies {
+		if *p.PolicyArn == adminPolicyARN {
+			return true, "", nil
 		}
 	}
 
@@ -160,20 +189,20 @@ func userHasAdminAccess(ctx context.Context, client IAMClient, userName string)
 		UserName: &userName,
 	})
 	if err != nil {
-		return false, nil
+		return false, "iam:ListGroupsForUser", err
 	}
 	for _, grp := range groupsOut.Groups {
 		grpPolicies, err := client.ListAttachedGroupPolicies(ctx, &iam.ListAttachedGroupPoliciesInput{
 			GroupName: grp.GroupName,
 		})
 		if err != nil {
-			continue
+			return false, "iam:ListAttachedGroupPolicies", err
 		}
 		for _, p := range grpPolicies.AttachedPolicies {
 			if *p.PolicyArn == adminPolicyARN {
-				return true, nil
+				return true, "", nil
 			}
 		}
 	}
-	return false, nil
+	return false, "", nil
 }
@@ -0,0 +1,268 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// AuthorizationDetails is every IAM user, group, role and managed policy of
+// the account, with their inline and managed policy documents.
+type AuthorizationDetails struct {
+	Users           []iamtypes.UserDetail
+	Groups          []iamtypes.GroupDetail
+	Roles           []iamtypes.RoleDetail
+	ManagedPolicies []iamtypes.ManagedPolicyDetail
+}
+
+// AuthorizationDetails returns the account's authorization details,
+// downloaded on first successful use and shared by the checks built on them.
+func (c *AWSClients) AuthorizationDetails(ctx context.Context) (*AuthorizationDetails, error) {
+	return c.details.get(func() (*AuthorizationDetails, error) {
+		return GetAuthorizationDetails(ctx, c.IAM)
+	})
+}
+
+// GetAuthorizationDetails downloads the authorization details of the
+// account, handling truncated responses.
+func GetAuthorizationDetails(ctx context.Context, client IAMClient) (*AuthorizationDetails, error) {
+	details := &AuthorizationDetails{}
+	var marker *string
+	for {
+		out, err := client.GetAccountAuthorizationDetails(ctx, &iam.GetAccountAuthorizationDetailsInput{Marker: marker})
+		if err != nil {
+			if isPermissionError(err) {
+				return nil, permissionSkip("iam:GetAccountAuthorizationDetails", err)
+			}
+			return nil, fmt.Errorf("GetAccountAuthorizationDetails: %w", err)
+		}
+		details.Users = append(details.Users, out.UserDetailList...)
+		details.Groups = append(details.Groups, out.GroupDetailList...)
+		details.Roles = append(details.Roles, out.RoleDetailList...)
+		details.ManagedPolicies = append(details.ManagedPolicies, out.Policies...)
+		if !out.IsTruncated {
+			break
+		}
+		marker = out.Marker
+	}
+	return details, nil
+}
+
+// IAMPolicy is a policy document granted to at least one principal: an
+// inline policy, or the default version of a managed policy.
+type IAMPolicy struct {
+	// ID identifies the policy in findings: the ARN of a managed policy, or
+	// "<kind>/<principal>/policy/<name>" for an inline policy.
+	ID         string
+	Name       string
+	AttachedTo []string
+	Document   PolicyDocument
+}
+
+// serviceRolePath is the path of service-linked roles, whose policies AWS
+// manages and which users cannot assume.
+const serviceRolePath = "/aws-service-role/"
+
+// Policies returns the inline policies of every user, group and role and
+// the default version of every attached managed policy. Policies granted
+// only to service-linked roles are left out.
+func (d *AuthorizationDetails) Policies() ([]IAMPolicy, error) {
+	var policies []IAMPolicy
+	attached := make(map[string][]string)
+	inline := func(kind, name string, list []iamtypes.PolicyDetail) error {
+		for _, p := range list {
+			doc, err := ParsePolicyDocument(aws.ToString(p.PolicyDocument))
+			if err != nil {
+				return fmt.Errorf("inline policy %s of %s %s: %w", aws.ToString(p.PolicyName), kind, name, err)
+			}
+			policies = append(policies, IAMPolicy{
+				ID:         fmt.Sprintf("%s/%s/policy/%s", kind, name, aws.ToString(p.PolicyName)),
+				Name:       aws.ToString(p.PolicyName),
+				AttachedTo: []string{kind + " " + name},
+				Document:   doc,
+			})
+		}
+		return nil
+	}
+	attach := func(kind, name string, list []iamtypes.AttachedPolicy) {
+		for _, p := range list {
+			arn := aws.ToString(p.PolicyArn)
+			attached[arn] = append(attached[arn], kind+" "+name)
+		}
+	}
+
+	for _, u := range d.Users {
+		if err := inline("user", aws.ToString(u.UserName), u.UserPolicyList); err != nil {
+			return nil, err
+		}
+		attach("user", aws.ToString(u.UserName), u.AttachedManagedPolicies)
+	}
+	for _, g := range d.Groups {
+		if err := inline("group", aws.ToString(g.GroupName), g.GroupPolicyList); err != nil {
+			return nil, err
+		}
+		attach("group", aws.ToString(g.GroupName), g.AttachedManagedPolicies)
+	}
+	for _, r := range d.Roles {
+		if aws.ToString(r.Path) == serviceRolePath {
+			continue
+		}
+		if err := inline("role", aws.ToString(r.RoleName), r.RolePolicyList); err != nil {
+			return nil, err
+		}
+		attach("role", aws.ToString(r.RoleName), r.AttachedManagedPolicies)
+	}
+
+	for _, p := range d.ManagedPolicies {
+		arn := aws.ToString(p.Arn)
+		if len(attached[arn]) == 0 {
+			continue
+		}
+		for _, v := range p.PolicyVersionList {
+			if !v.IsDefaultVersion {
+				continue
+			}
+			doc, err := ParsePolicyDocument(aws.ToString(v.Document))
+			if err != nil {
+				return nil, fmt.Errorf("managed policy %s: %w", arn, err)
+			}
+			policies = append(policies, IAMPolicy{
+				ID:         arn,
+				Name:       aws.ToString(p.PolicyName),
+				AttachedTo: attached[arn],
+				Document:   doc,
+			})
+		}
+	}
+	return policies, nil
+}
+
+// sensitiveServices are the services for which a service-wide wildcard on
+// every resource is reported by iam-policy-wildcard-resource. iam:* has its
+// own check.
+var sensitiveServices = []string{
+	"cloudtrail", "dynamodb", "ec2", "kms", "lambda", "organizations",
+	"rds", "s3", "secretsmanager", "ssm", "sts",
+}
+
+// policyCheck describes a check of CheckPolicies.
+type policyCheck struct {
+	id             string
+	severity       string
+	grants         string
+	recommendation string
+	// match returns what the statement grants that the check reports, or
+	// nothing.
+	match func(PolicyStatement) []string
+}
+
+var policyChecks = []policyCheck{
+	{
+		id:             "iam-policy-full-admin",
+		severity:       "CRITICAL",
+		grants:         "full administrative access",
+		recommendation: "Replace \"*\" actions on \"*\" with the actions and resources the principals need",
+		match: func(s PolicyStatement) []string {
+			if s.AllResources() && (s.Action.contains("*") || s.Action.contains("*:*")) {
+				return []string{`"*" on "*"`}
+			}
+			return nil
+		},
+	},
+	{
+		id:             "iam-policy-iam-wildcard",
+		severity:       "HIGH",
+		grants:         "every IAM action",
+		recommendation: "Grant only the IAM actions the principals need; iam:* allows them to grant themselves any permission",
+		match: func(s PolicyStatement) []string {
+			if s.Action.contains("iam:*") {
+				return []string{`"iam:*"`}
+			}
+			return nil
+		},
+	},
+	{
+		id:             "iam-policy-wildcard-resource",
+		severity:       "MEDIUM",
+		grants:         "every action of a sensitive service on every resource",
+		recommendation: "Scope the statement to the resources the principals use, or to fewer actions",
+		match: func(s PolicyStatement) []string {
+			if !s.AllResources() {
+				return nil
+			}
+			var matched []string
+			for _, svc := range sensitiveServices {
+				if s.Action.contains(svc + ":*") {
+					matched = append(matched, fmt.Sprintf(`"%s:*" on "*"`, svc))
+				}
+			}
+			return matched
+		},
+	},
+	{
+		id:             "iam-policy-not-action",
+		severity:       "HIGH",
+		grants:         "every action except those listed",
+		recommendation: "Replace NotAction with an explicit list of allowed actions",
+		match: func(s PolicyStatement) []string {
+			if len(s.NotAction) > 0 {
+				return []string{"NotAction " + strings.Join(s.NotAction, ", ")}
+			}
+			return nil
+		},
+	},
+}
+
+// CheckPolicies analyzes the Allow statements of every policy for each
+// policy check. A statement granting full administrative access is reported
+// by iam-policy-full-admin only. Each finding cites the statements that
+// caused it.
+func CheckPolicies(ctx context.Context, policies []IAMPolicy) []reporter.CheckResult {
+	var results []reporter.CheckResult
+	for _, p := range policies {
+		subject := fmt.Sprintf("Policy %q (%s)", p.Name, attachedTo(p.AttachedTo))
+		for _, pc := range policyChecks {
+			var cited []string
+			for i, s := range p.Document.Statement {
+				if !s.Allows() {
+					continue
+				}
+				if pc.id != "iam-policy-full-admin" && policyChecks[0].match(s) != nil {
+					continue
+				}
+				for _, m := range pc.match(s) {
+					cited = append(cited, fmt.Sprintf("%s in %s", m, s.Label(i)))
+				}
+			}
+			if len(cited) == 0 {
+				check.Pass(ctx, pc.id, p.ID, fmt.Sprintf("%s does not grant %s", subject, pc.grants))
+				continue
+			}
+			results = append(results, reporter.CheckResult{
+				CheckName:      pc.id,
+				Severity:       pc.severity,
+				ResourceID:     p.ID,
+				Message:        fmt.Sprintf("%s grants %s: %s", subject, pc.grants, strings.Join(cited, "; ")),
+				Recommendation: pc.recommendation,
+			})
+		}
+	}
+	return results
+}
+
+// attachedTo describes the principals of a policy, naming at most three.
+func attachedTo(principals []string) string {
+	sorted := append([]string(nil), principals...)
+	sort.Strings(sorted)
+	if len(sorted) <= 3 {
+		return "attached to " + strings.Join(sorted, ", ")
+	}
+	return fmt.Sprintf("attached to %s and %d more", strings.Join(sorted[:3], ", "), len(sorted)-3)
+}
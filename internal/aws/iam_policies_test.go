@@ -0,0 +1,161 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
+)
+
+// policyDoc returns a policy document URL-encoded as IAM returns it.
+func policyDoc(statements string) *string {
+	return aws.String(url.QueryEscape(`{"Version":"2012-10-17","Statement":` + statements + `}`))
+}
+
+// testAuthorizationDetails has one policy of each kind, in two pages.
+func testAuthorizationDetails() []*iam.GetAccountAuthorizationDetailsOutput {
+	return []*iam.GetAccountAuthorizationDetailsOutput{
+		{
+			UserDetailList: []iamtypes.UserDetail{{
+				UserName: aws.String("alice"),
+				UserPolicyList: []iamtypes.PolicyDetail{{
+					PolicyName:     aws.String("everything"),
+					PolicyDocument: policyDoc(`[{"Sid":"All","Effect":"Allow","Action":"*","Resource":"*"}]`),
+				}},
+				AttachedManagedPolicies: []iamtypes.AttachedPolicy{{PolicyArn: aws.String("arn:aws:iam::123456789012:policy/ci")}},
+			}},
+			GroupDetailList: []iamtypes.GroupDetail{{
+				GroupName: aws.String("readers"),
+				GroupPolicyList: []iamtypes.PolicyDetail{{
+					PolicyName:     aws.String("read"),
+					PolicyDocument: policyDoc(`{"Effect":"Allow","Action":["s3:GetObject"],"Resource":"*"}`),
+				}},
+			}},
+		},
+		{
+			RoleDetailList: []iamtypes.RoleDetail{
+				{
+					RoleName: aws.String("deploy"),
+					Path:     aws.String("/"),
+					RolePolicyList: []iamtypes.PolicyDetail{{
+						PolicyName:     aws.String("almost-admin"),
+						PolicyDocument: policyDoc(`[{"Effect":"Allow","NotAction":"iam:*","Resource":"*"},{"Effect":"Deny","Action":"*","Resource":"*"}]`),
+					}},
+					AttachedManagedPolicies: []iamtypes.AttachedPolicy{{PolicyArn: aws.String("arn:aws:iam::123456789012:policy/ci")}},
+				},
+				{
+					RoleName: aws.String("AWSServiceRoleForSupport"),
+					Path:     aws.String("/aws-service-role/"),
+					RolePolicyList: []iamtypes.PolicyDetail{{
+						PolicyName:     aws.String("service"),
+						PolicyDocument: policyDoc(`{"Effect":"Allow","Action":"*","Resource":"*"}`),
+					}},
+				},
+			},
+			Policies: []iamtypes.ManagedPolicyDetail{
+				{
+					Arn:        aws.String("arn:aws:iam::123456789012:policy/ci"),
+					PolicyName: aws.String("ci"),
+					PolicyVersionList: []iamtypes.PolicyVersion{
+						{VersionId: aws.String("v1"), Document: policyDoc(`{"Effect":"Allow","Action":"*","Resource":"*"}`)},
+						{VersionId: aws.String("v2"), IsDefaultVersion: true, Document: policyDoc(`[{"Sid":"IAM","Effect":"Allow","Action":["iam:*","s3:*","kms:*"],"Resource":"*"}]`)},
+					},
+				},
+				{
+					Arn:        aws.String("arn:aws:iam::123456789012:policy/unused"),
+					PolicyName: aws.String("unused"),
+					PolicyVersionList: []iamtypes.PolicyVersion{
+						{IsDefaultVersion: true, Document: policyDoc(`{"Effect":"Allow","Action":"*","Resource":"*"}`)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAuthorizationDetails_Policies(t *testing.T) {
+	details, err := GetAuthorizationDetails(context.Background(), &mockIAMClient{authorizationDetailsOutputs: testAuthorizationDetails()})
+	if err != nil {
+		t.Fatalf("GetAuthorizationDetails() error = %v", err)
+	}
+	if len(details.Users) != 1 || len(details.Roles) != 2 || len(details.ManagedPolicies) != 2 {
+		t.Fatalf("pages not merged: %+v", details)
+	}
+
+	policies, err := details.Policies()
+	if err != nil {
+		t.Fatalf("Policies() error = %v", err)
+	}
+	var ids []string
+	for _, p := range policies {
+		ids = append(ids, p.ID)
+	}
+	want := "user/alice/policy/everything group/readers/policy/read role/deploy/policy/almost-admin arn:aws:iam::123456789012:policy/ci"
+	if got := strings.Join(ids, " "); got != want {
+		t.Errorf("policies = %s, want %s", got, want)
+	}
+	if ci := policies[3]; len(ci.AttachedTo) != 2 || ci.Document.Statement[0].Sid != "IAM" {
+		t.Errorf("ci = %+v, want the default version attached to alice and deploy", ci)
+	}
+}
+
+func TestCheckPolicies(t *testing.T) {
+	details, _ := GetAuthorizationDetails(context.Background(), &mockIAMClient{authorizationDetailsOutputs: testAuthorizationDetails()})
+	policies, err := details.Policies()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	for _, r := range CheckPolicies(context.Background(), policies) {
+		got[r.CheckName+" "+r.ResourceID] = r.Message
+	}
+	want := map[string]string{
+		"iam-policy-full-admin user/alice/policy/everything":               `"*" on "*" in statement "All"`,
+		"iam-policy-not-action role/deploy/policy/almost-admin":            `NotAction iam:* in statement 1`,
+		"iam-policy-iam-wildcard arn:aws:iam::123456789012:policy/ci":      `"iam:*" in statement "IAM"`,
+		"iam-policy-wildcard-resource arn:aws:iam::123456789012:policy/ci": `"kms:*" on "*" in statement "IAM"; "s3:*" on "*" in statement "IAM"`,
+	}
+	if len(got) != len(want) {
+		t.Errorf("findings = %v, want %d", got, len(want))
+	}
+	for key, cite := range want {
+		if !strings.HasSuffix(got[key], cite) {
+			t.Errorf("%s: message = %q, want it to cite %s", key, got[key], cite)
+		}
+	}
+	if msg := got["iam-policy-iam-wildcard arn:aws:iam::123456789012:policy/ci"]; !strings.Contains(msg, "attached to role deploy, user alice") {
+		t.Errorf("message does not name the principals: %s", msg)
+	}
+}
+
+func TestIAMPolicyChecks_Run(t *testing.T) {
+	mock := &mockIAMClient{authorizationDetailsOutputs: testAuthorizationDetails()}
+	opts := check.Options{IncludePassed: true, Enabled: func(id string) bool { return strings.HasPrefix(id, "iam-policy-") }}
+
+	outcomes := check.Run(context.Background(), &AWSClients{IAM: mock}, Checks(appconfig.DefaultConfig().AWS), opts)
+	for _, o := range outcomes {
+		if !strings.HasPrefix(o.ID, "iam-policy-") {
+			continue
+		}
+		if o.Status != check.StatusFailed || o.Evaluated != 4 {
+			t.Errorf("%s: status %s, evaluated %d, want failed with 4 policies", o.ID, o.Status, o.Evaluated)
+		}
+	}
+}
+
+func TestGetAuthorizationDetails_PermissionError(t *testing.T) {
+	mock := &mockIAMClient{authorizationDetailsErr: errors.New("AccessDenied: not authorized")}
+	_, err := GetAuthorizationDetails(context.Background(), mock)
+	var skip *check.SkipError
+	if !errors.As(err, &skip) || skip.Reason != "AccessDenied on iam:GetAccountAuthorizationDetails" {
+		t.Errorf("err = %v, want a skip naming iam:GetAccountAuthorizationDetails", err)
+	}
+}
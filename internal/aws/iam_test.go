@@ -3,12 +3,16 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
 type mockIAMClient struct {
@@ -24,6 +28,12 @@ type mockIAMClient struct {
 	listGroupsForUserErr            error
 	listAttachedGroupPoliciesOutput *iam.ListAttachedGroupPoliciesOutput
 	listAttachedGroupPoliciesErr    error
+	generateCredentialReportOutput  *iam.GenerateCredentialReportOutput
+	generateCredentialReportErr     error
+	getCredentialReportOutput       *iam.GetCredentialReportOutput
+	getCredentialReportErr          error
+	authorizationDetailsOutputs     []*iam.GetAccountAuthorizationDetailsOutput
+	authorizationDetailsErr         error
 }
 
 func (m *mockIAMClient) ListUsers(_ context.Context, _ *iam.ListUsersInput, _ ...func(*iam.Options)) (*iam.ListUsersOutput, error) {
@@ -44,6 +54,29 @@ func (m *mockIAMClient) ListGroupsForUser(_ context.Context, _ *iam.ListGroupsFo
 func (m *mockIAMClient) ListAttachedGroupPolicies(_ context.Context, _ *iam.ListAttachedGroupPoliciesInput, _ ...func(*iam.Options)) (*iam.ListAttachedGroupPoliciesOutput, error) {
 	return m.listAttachedGroupPoliciesOutput, m.listAttachedGroupPoliciesErr
 }
+func (m *mockIAMClient) GenerateCredentialReport(_ context.Context, _ *iam.GenerateCredentialReportInput, _ ...func(*iam.Options)) (*iam.GenerateCredentialReportOutput, error) {
+	return m.generateCredentialReportOutput, m.generateCredentialReportErr
+}
+func (m *mockIAMClient) GetCredentialReport(_ context.Context, _ *iam.GetCredentialReportInput, _ ...func(*iam.Options)) (*iam.GetCredentialReportOutput, error) {
+	return m.getCredentialReportOutput, m.getCredentialReportErr
+}
+
+// GetAccountAuthorizationDetails returns authorizationDetailsOutputs one page
+// at a time, using the page index as the marker.
+func (m *mockIAMClient) GetAccountAuthorizationDetails(_ context.Context, in *iam.GetAccountAuthorizationDetailsInput, _ ...func(*iam.Options)) (*iam.GetAccountAuthorizationDetailsOutput, error) {
+	if m.authorizationDetailsErr != nil {
+		return nil, m.authorizationDetailsErr
+	}
+	page := 0
+	if in.Marker != nil {
+		page, _ = strconv.Atoi(*in.Marker)
+	}
+	out := *m.authorizationDetailsOutputs[page]
+	if page+1 < len(m.authorizationDetailsOutputs) {
+		out.IsTruncated, out.Marker = true, aws.String(strconv.Itoa(page+1))
+	}
+	return &out, nil
+}
 
 func TestCheckIAMUsersMFA_NoMFA(t *testing.T) {
 	mock := &mockIAMClient{
@@ -84,14 +117,51 @@ func TestCheckIAMUsersMFA_PermissionError(t *testing.T) {
 		listUsersErr: fmt.Errorf("AccessDenied: not authorized"),
 	}
 	results, err := CheckIAMUsersMFA(context.Background(), mock)
-	if err != nil {
-		t.Errorf("expected graceful skip on permission error, got: %v", err)
-	}
+	assertSkipped(t, err, "AccessDenied on iam:ListUsers")
 	if len(results) != 0 {
 		t.Errorf("expected no results on permission error, got %d", len(results))
 	}
 }
 
+// mfaDeniedFor denies ListMFADevices for some users.
+type mfaDeniedFor struct {
+	*mockIAMClient
+	denied map[string]bool
+}
+
+func (m mfaDeniedFor) ListMFADevices(ctx context.Context, in *iam.ListMFADevicesInput, opts ...func(*iam.Options)) (*iam.ListMFADevicesOutput, error) {
+	if m.denied[aws.ToString(in.UserName)] {
+		return nil, fmt.Errorf("AccessDenied: not authorized to perform iam:ListMFADevices")
+	}
+	return m.mockIAMClient.ListMFADevices(ctx, in, opts...)
+}
+
+func TestCheckIAMUsersMFA_SomeDenied(t *testing.T) {
+	mock := mfaDeniedFor{
+		mockIAMClient: &mockIAMClient{
+			listUsersOutput: &iam.ListUsersOutput{Users: []iamtypes.User{
+				{UserName: aws.String("alice")}, {UserName: aws.String("bob")}, {UserName: aws.String("carol")},
+			}},
+			listMFADevicesOutput: &iam.ListMFADevicesOutput{MFADevices: []iamtypes.MFADevice{{SerialNumber: aws.String("arn:aws:iam::123:mfa/token")}}},
+		},
+		denied: map[string]bool{"bob": true, "carol": true},
+	}
+	checks := []check.Check[IAMClient]{
+		check.New("iam-mfa-disabled", func(ctx context.Context, c IAMClient) ([]reporter.CheckResult, error) {
+			return CheckIAMUsersMFA(ctx, c)
+		}),
+	}
+
+	o := check.Run[IAMClient](context.Background(), mock, checks, check.Options{})[0]
+	// The check passed on the user it could read, and says what it missed.
+	if o.Status != check.StatusPassed || o.Evaluated != 1 {
+		t.Errorf("status %s, evaluated %d, want passed on 1 user", o.Status, o.Evaluated)
+	}
+	if want := "2 of 3 users denied: AccessDenied on iam:ListMFADevices"; o.Partial != want {
+		t.Errorf("Partial = %q, want %q", o.Partial, want)
+	}
+}
+
 func TestCheckIAMAccessKeyAge_Medium(t *testing.T) {
 	created := time.Now().AddDate(0, 0, -100)
 	mock := &mockIAMClient{
@@ -191,3 +261,32 @@ func TestCheckIAMAdminUsers_NoAdmin(t *testing.T) {
 		t.Errorf("expected no results for non-admin user, got %d", len(results))
 	}
 }
+
+func TestCheckIAMAdminUsers_GroupsDenied(t *testing.T) {
+	mock := &mockIAMClient{
+		listUsersOutput:                &iam.ListUsersOutput{Users: []iamtypes.User{{UserName: aws.String("alice")}}},
+		listAttachedUserPoliciesOutput: &iam.ListAttachedUserPoliciesOutput{},
+		listGroupsForUserErr:           fmt.Errorf("AccessDenied: not authorized to perform iam:ListGroupsForUser"),
+	}
+	checks := []check.Check[IAMClient]{
+		check.New("iam-admin-access", func(ctx context.Context, c IAMClient) ([]reporter.CheckResult, error) {
+			return CheckIAMAdminUsers(ctx, c)
+		}),
+	}
+
+	// A user whose groups cannot be listed is not evaluated, let alone passed.
+	o := check.Run[IAMClient](context.Background(), mock, checks, check.Options{IncludePassed: true})[0]
+	if o.Status != check.StatusSkipped || o.SkipReason != "AccessDenied on iam:ListGroupsForUser" || len(o.Passed) != 0 {
+		t.Errorf("outcome = %+v, want skipped with AccessDenied on iam:ListGroupsForUser", o)
+	}
+}
+
+func TestCheckIAMAdminUsers_Error(t *testing.T) {
+	mock := &mockIAMClient{
+		listUsersOutput:             &iam.ListUsersOutput{Users: []iamtypes.User{{UserName: aws.String("alice")}}},
+		listAttachedUserPoliciesErr: fmt.Errorf("throttled"),
+	}
+	if _, err := CheckIAMAdminUsers(context.Background(), mock); err == nil || !strings.Contains(err.Error(), "iam:ListAttachedUserPolicies for alice") {
+		t.Errorf("expected an error naming the failed call, got %v", err)
+	}
+}
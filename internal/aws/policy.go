@@ -0,0 +1,192 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PolicyDocument is an IAM policy document. Only the parts devopsctl
+// analyzes are decoded.
+type PolicyDocument struct {
+	Statement []PolicyStatement
+}
+
+// PolicyStatement is one statement of a policy document. Action, Resource
+// and their Not forms accept a single string or a list. Principal is only
+// set in resource policies such as role trust policies.
+type PolicyStatement struct {
+	Sid         string
+	Effect      string
+	Principal   PolicyPrincipal
+	Action      stringList
+	NotAction   stringList
+	Resource    stringList
+	NotResource stringList
+	Condition   map[string]map[string]json.RawMessage
+}
+
+// PolicyPrincipal maps a principal type (AWS, Service, Federated) to its
+// principals. "Principal": "*" is stored as {"AWS": ["*"]}.
+type PolicyPrincipal map[string]stringList
+
+func (p *PolicyPrincipal) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*p = PolicyPrincipal{"AWS": {s}}
+		return nil
+	}
+	var m map[string]stringList
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*p = m
+	return nil
+}
+
+// stringList is a policy element that may be a single string or a list.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*l = stringList{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// UnmarshalJSON accepts a single statement object as well as a list.
+func (d *PolicyDocument) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Statement json.RawMessage
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Statement) == 0 {
+		return nil
+	}
+	if raw.Statement[0] == '{' {
+		var s PolicyStatement
+		if err := json.Unmarshal(raw.Statement, &s); err != nil {
+			return err
+		}
+		d.Statement = []PolicyStatement{s}
+		return nil
+	}
+	return json.Unmarshal(raw.Statement, &d.Statement)
+}
+
+// ParsePolicyDocument parses a policy document as IAM returns it: JSON,
+// URL-encoded.
+func ParsePolicyDocument(encoded string) (PolicyDocument, error) {
+	var doc PolicyDocument
+	text, err := url.QueryUnescape(encoded)
+	if err != nil {
+		return doc, fmt.Errorf("decode policy document: %w", err)
+	}
+	if err := json.Unmarshal([]byte(text), &doc); err != nil {
+		return doc, fmt.Errorf("parse policy document: %w", err)
+	}
+	return doc, nil
+}
+
+// Allows reports whether the statement is an Allow statement.
+func (s PolicyStatement) Allows() bool {
+	return strings.EqualFold(s.Effect, "Allow")
+}
+
+// Label names the statement in findings: its Sid, or its 1-based position
+// in the document when it has none.
+func (s PolicyStatement) Label(i int) string {
+	if s.Sid != "" {
+		return fmt.Sprintf("statement %q", s.Sid)
+	}
+	return fmt.Sprintf("statement %d", i+1)
+}
+
+// AllResources reports whether the statement applies to every resource.
+func (s PolicyStatement) AllResources() bool {
+	return s.Resource.contains("*")
+}
+
+// contains reports whether the list holds v, ignoring case as IAM does for
+// actions.
+func (l stringList) contains(v string) bool {
+	for _, s := range l {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether the statement applies to action on resource. An
+// empty resource matches a statement on any resource.
+func (s PolicyStatement) Matches(action, resource string) bool {
+	if len(s.NotAction) > 0 {
+		if s.NotAction.matches(action, true) {
+			return false
+		}
+	} else if !s.Action.matches(action, true) {
+		return false
+	}
+	if resource == "" {
+		return true
+	}
+	if len(s.NotResource) > 0 {
+		return !s.NotResource.matches(resource, false)
+	}
+	return s.Resource.matches(resource, false)
+}
+
+// matches reports whether any pattern of the list matches s, with the IAM
+// wildcards * and ?. Actions match regardless of case, resources do not.
+func (l stringList) matches(s string, foldCase bool) bool {
+	for _, pattern := range l {
+		if foldCase {
+			pattern, s = strings.ToLower(pattern), strings.ToLower(s)
+		}
+		if wildcardMatch(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// wildcardMatch matches s against pattern, where * matches any run of
+// characters and ? any single character.
+func wildcardMatch(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			pattern = strings.TrimLeft(pattern, "*")
+			if pattern == "" {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if wildcardMatch(pattern, s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if s == "" {
+				return false
+			}
+		default:
+			if s == "" || s[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return s == ""
+}
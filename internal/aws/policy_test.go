@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicyDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []PolicyStatement
+	}{
+		{
+			name: "single statement and strings",
+			doc:  `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`,
+			want: []PolicyStatement{{Effect: "Allow", Action: stringList{"*"}, Resource: stringList{"*"}}},
+		},
+		{
+			name: "statement list and lists",
+			doc: `{"Version":"2012-10-17","Statement":[
+				{"Sid":"Read","Effect":"Allow","Action":["s3:GetObject","s3:ListBucket"],"Resource":["arn:aws:s3:::logs","arn:aws:s3:::logs/*"]},
+				{"Effect":"Deny","NotAction":"iam:*","NotResource":"arn:aws:iam::*:role/admin"}]}`,
+			want: []PolicyStatement{
+				{Sid: "Read", Effect: "Allow", Action: stringList{"s3:GetObject", "s3:ListBucket"}, Resource: stringList{"arn:aws:s3:::logs", "arn:aws:s3:::logs/*"}},
+				{Effect: "Deny", NotAction: stringList{"iam:*"}, NotResource: stringList{"arn:aws:iam::*:role/admin"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParsePolicyDocument(url.QueryEscape(tt.doc))
+			if err != nil {
+				t.Fatalf("ParsePolicyDocument() error = %v", err)
+			}
+			if !reflect.DeepEqual(doc.Statement, tt.want) {
+				t.Errorf("statements = %+v, want %+v", doc.Statement, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePolicyDocument_Invalid(t *testing.T) {
+	for _, doc := range []string{"%zz", url.QueryEscape(`{"Statement":{"Action":1}}`)} {
+		if _, err := ParsePolicyDocument(doc); err == nil {
+			t.Errorf("ParsePolicyDocument(%q) expected an error", doc)
+		}
+	}
+}
+
+func TestPolicyStatement_Label(t *testing.T) {
+	if got := (PolicyStatement{Sid: "Admin"}).Label(0); got != `statement "Admin"` {
+		t.Errorf("Label() = %s", got)
+	}
+	if got := (PolicyStatement{}).Label(2); got != "statement 3" {
+		t.Errorf("Label() = %s", got)
+	}
+}
+
+func TestPolicyStatement_Matches(t *testing.T) {
+	s := PolicyStatement{Action: stringList{"iam:Put*", "s3:Get?bject"}, Resource: stringList{"arn:aws:iam::*:role/app-*"}}
+	tests := []struct {
+		action, resource string
+		want             bool
+	}{
+		{"iam:PutRolePolicy", "", true},
+		{"IAM:putrolepolicy", "", true},
+		{"s3:GetObject", "", true},
+		{"s3:GetObjects", "", false},
+		{"iam:PassRole", "", false},
+		{"iam:PutRolePolicy", "arn:aws:iam::123456789012:role/app-web", true},
+		{"iam:PutRolePolicy", "arn:aws:iam::123456789012:role/admin", false},
+	}
+	for _, tt := range tests {
+		if got := s.Matches(tt.action, tt.resource); got != tt.want {
+			t.Errorf("Matches(%q, %q) = %v, want %v", tt.action, tt.resource, got, tt.want)
+		}
+	}
+
+	not := PolicyStatement{NotAction: stringList{"iam:*"}, NotResource: stringList{"arn:aws:s3:::secret"}}
+	if !not.Matches("s3:GetObject", "arn:aws:s3:::public") || not.Matches("iam:PassRole", "") || not.Matches("s3:GetObject", "arn:aws:s3:::secret") {
+		t.Error("NotAction and NotResource not applied")
+	}
+}
@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// regionConcurrency bounds how many regions a regional check queries at
+// once, to stay clear of API rate limits.
+const regionConcurrency = 5
+
+// RegionalEC2 is the EC2 client of one scanned region.
+type RegionalEC2 struct {
+	Region string
+	Client EC2Client
+}
+
+// RegionalEC2 returns the EC2 client of every scanned region, sorted by
+// region. With config.AllRegions the regions enabled for the account are
+// discovered on first successful use.
+func (c *AWSClients) RegionalEC2(ctx context.Context) ([]RegionalEC2, error) {
+	return c.regional.get(func() ([]RegionalEC2, error) {
+		return c.resolveRegions(ctx)
+	})
+}
+
+func (c *AWSClients) resolveRegions(ctx context.Context) ([]RegionalEC2, error) {
+	regions := []string(c.Regions)
+	if c.Regions.All() {
+		out, err := c.EC2.DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+		if err != nil {
+			if isPermissionError(err) {
+				return nil, permissionSkip("ec2:DescribeRegions", err)
+			}
+			return nil, fmt.Errorf("DescribeRegions: %w", err)
+		}
+		regions = nil
+		for _, r := range out.Regions {
+			regions = append(regions, aws.ToString(r.RegionName))
+		}
+	}
+	if len(regions) == 0 {
+		return []RegionalEC2{{Region: c.awsCfg.Region, Client: c.EC2}}, nil
+	}
+
+	sort.Strings(regions)
+	clients := make([]RegionalEC2, 0, len(regions))
+	for _, region := range regions {
+		client := c.EC2
+		if region != c.awsCfg.Region && c.NewEC2 != nil {
+			client = c.NewEC2(region)
+		}
+		clients = append(clients, RegionalEC2{Region: region, Client: client})
+	}
+	return clients, nil
+}
+
+// SecurityGroups returns the security groups of region, described with
+// client on first successful use and shared by the checks built on them.
+func (c *AWSClients) SecurityGroups(ctx context.Context, client EC2Client, region string) ([]ec2types.SecurityGroup, error) {
+	c.sgMu.Lock()
+	if c.sgScans == nil {
+		c.sgScans = make(map[string]*cached[[]ec2types.SecurityGroup])
+	}
+	scan, ok := c.sgScans[region]
+	if !ok {
+		scan = &cached[[]ec2types.SecurityGroup]{}
+		c.sgScans[region] = scan
+	}
+	c.sgMu.Unlock()
+
+	return scan.get(func() ([]ec2types.SecurityGroup, error) {
+		return describeSecurityGroups(ctx, client)
+	})
+}
+
+// regional runs fn against every scanned region, at most regionConcurrency
+// at a time, and merges the findings in region order. Like a check denied on
+// every resource, a check is only skipped when every region skipped it, and
+// the regions that skipped are recorded as not evaluated otherwise; other
+// errors are returned with the findings of the regions that succeeded.
+func regional(fn func(ctx context.Context, client EC2Client, region string) ([]reporter.CheckResult, error)) check.Func[*AWSClients] {
+	return func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+		clients, err := c.RegionalEC2(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([][]reporter.CheckResult, len(clients))
+		errs := make([]error, len(clients))
+		sem := make(chan struct{}, regionConcurrency)
+		var wg sync.WaitGroup
+		for i, rc := range clients {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i], errs[i] = fn(ctx, rc.Client, rc.Region)
+			}()
+		}
+		wg.Wait()
+
+		var all []reporter.CheckResult
+		var failed []string
+		var skip error
+		var skipReason string
+		skipped := 0
+		for i, rc := range clients {
+			all = append(all, results[i]...)
+			var skipErr *check.SkipError
+			switch {
+			case errs[i] == nil:
+			case errors.As(errs[i], &skipErr):
+				skipped++
+				skip, skipReason = errs[i], skipErr.Reason
+			default:
+				failed = append(failed, fmt.Sprintf("%s: %v", rc.Region, errs[i]))
+			}
+		}
+		if len(failed) > 0 {
+			return all, errors.New(strings.Join(failed, "; "))
+		}
+		if skipped == len(clients) {
+			return nil, skip
+		}
+		if skipped > 0 {
+			check.Partial(ctx, fmt.Sprintf("%d of %d regions skipped: %s", skipped, len(clients), skipReason))
+		}
+		return all, nil
+	}
+}
+
+// regionalID prefixes a regional resource ID with its region, e.g.
+// "eu-west-1/sg-0123".
+func regionalID(region, id string) string {
+	if region == "" {
+		return id
+	}
+	return region + "/" + id
+}
@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
+)
+
+func unencryptedVolume(id string) *mockEC2Client {
+	return &mockEC2Client{
+		describeVolumesOutput: &ec2.DescribeVolumesOutput{
+			Volumes: []ec2types.Volume{{VolumeId: aws.String(id), Encrypted: aws.Bool(false)}},
+		},
+	}
+}
+
+func TestRegional_FansOutAcrossRegions(t *testing.T) {
+	clients := &AWSClients{
+		EC2:     &mockEC2Client{},
+		Regions: appconfig.Regions{"us-east-1", "eu-west-1"},
+		NewEC2: func(region string) EC2Client {
+			return unencryptedVolume("vol-" + region)
+		},
+	}
+
+	results, err := regional(CheckEBSEncryption)(context.Background(), clients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"eu-west-1/vol-eu-west-1", "us-east-1/vol-us-east-1"}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %v", len(want), results)
+	}
+	for i, r := range results {
+		if r.ResourceID != want[i] {
+			t.Errorf("results[%d].ResourceID = %q, want %q", i, r.ResourceID, want[i])
+		}
+	}
+}
+
+func TestRegional_AllRegions(t *testing.T) {
+	clients := &AWSClients{
+		EC2: &mockEC2Client{
+			describeRegionsOutput: &ec2.DescribeRegionsOutput{
+				Regions: []ec2types.Region{{RegionName: aws.String("ap-south-1")}, {RegionName: aws.String("ca-central-1")}},
+			},
+		},
+		Regions: appconfig.Regions{appconfig.AllRegions},
+		NewEC2: func(region string) EC2Client {
+			return unencryptedVolume("vol-1")
+		},
+	}
+
+	regions, err := clients.RegionalEC2(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(regions) != 2 || regions[0].Region != "ap-south-1" || regions[1].Region != "ca-central-1" {
+		t.Errorf("RegionalEC2() = %+v, want ap-south-1 and ca-central-1", regions)
+	}
+}
+
+func TestRegional_AllRegionsDenied(t *testing.T) {
+	clients := &AWSClients{
+		EC2:     &mockEC2Client{describeRegionsErr: fmt.Errorf("UnauthorizedOperation: not allowed")},
+		Regions: appconfig.Regions{appconfig.AllRegions},
+	}
+
+	_, err := regional(CheckEBSEncryption)(context.Background(), clients)
+	assertSkipped(t, err, "UnauthorizedOperation on ec2:DescribeRegions")
+}
+
+func TestRegional_SkipsOnlyWhenEveryRegionSkips(t *testing.T) {
+	denied := &mockEC2Client{describeVolumesErr: fmt.Errorf("AccessDenied: not allowed")}
+	clients := func(allowed EC2Client) *AWSClients {
+		return &AWSClients{
+			EC2:     &mockEC2Client{},
+			Regions: appconfig.Regions{"eu-west-1", "us-east-1"},
+			NewEC2: func(region string) EC2Client {
+				if region == "eu-west-1" {
+					return denied
+				}
+				return allowed
+			},
+		}
+	}
+
+	results, err := regional(CheckEBSEncryption)(context.Background(), clients(unencryptedVolume("vol-1")))
+	if err != nil {
+		t.Fatalf("expected no error when one region is allowed, got %v", err)
+	}
+	if len(results) != 1 || results[0].ResourceID != "us-east-1/vol-1" {
+		t.Errorf("expected the finding of the allowed region, got %v", results)
+	}
+
+	_, err = regional(CheckEBSEncryption)(context.Background(), clients(denied))
+	assertSkipped(t, err, "AccessDenied on ec2:DescribeVolumes")
+}
+
+func TestRegional_PartialError(t *testing.T) {
+	clients := &AWSClients{
+		EC2:     &mockEC2Client{},
+		Regions: appconfig.Regions{"eu-west-1", "us-east-1"},
+		NewEC2: func(region string) EC2Client {
+			if region == "eu-west-1" {
+				return &mockEC2Client{describeVolumesErr: fmt.Errorf("throttled")}
+			}
+			return unencryptedVolume("vol-1")
+		},
+	}
+
+	results, err := regional(CheckEBSEncryption)(context.Background(), clients)
+	if err == nil || !strings.Contains(err.Error(), "eu-west-1: ") {
+		t.Errorf("expected an error naming eu-west-1, got %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("expected the findings of us-east-1 with the error, got %v", results)
+	}
+}
@@ -0,0 +1,212 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// CheckRoleTrust audits the trust policy of every role that is not
+// service-linked for principals it should not trust:
+//   - iam-role-trust-wildcard: Principal "*" without conditions;
+//   - iam-role-trust-external-account: an account other than the role's own
+//     that is not in trusted;
+//   - iam-role-trust-no-external-id: such an account without an
+//     sts:ExternalId condition;
+//   - iam-role-trust-oidc-no-sub: an OIDC provider without a condition on
+//     the token's sub claim.
+//
+// Each finding cites the statements that caused it.
+// Severity: CRITICAL for wildcard principals, HIGH otherwise.
+func CheckRoleTrust(ctx context.Context, roles []iamtypes.RoleDetail, trusted []string) ([]reporter.CheckResult, error) {
+	trustedSet := make(map[string]bool, len(trusted))
+	for _, id := range trusted {
+		trustedSet[id] = true
+	}
+
+	var results []reporter.CheckResult
+	for _, r := range roles {
+		if aws.ToString(r.Path) == serviceRolePath {
+			continue
+		}
+		name := aws.ToString(r.RoleName)
+		trust, err := ParsePolicyDocument(aws.ToString(r.AssumeRolePolicyDocument))
+		if err != nil {
+			return nil, fmt.Errorf("trust policy of role %s: %w", name, err)
+		}
+		own := accountOf(aws.ToString(r.Arn))
+
+		cited := make(map[string][]string)
+		for i, s := range trust.Statement {
+			if !s.Allows() {
+				continue
+			}
+			label := s.Label(i)
+			for _, p := range s.Principal["AWS"] {
+				if p == "*" {
+					if len(s.Condition) == 0 {
+						cited["iam-role-trust-wildcard"] = append(cited["iam-role-trust-wildcard"], fmt.Sprintf(`"*" in %s`, label))
+					}
+					continue
+				}
+				account := principalAccount(p)
+				if account == "" || account == own || trustedSet[account] {
+					continue
+				}
+				cited["iam-role-trust-external-account"] = append(cited["iam-role-trust-external-account"], fmt.Sprintf("account %s in %s", account, label))
+				if s.conditionValues("sts:ExternalId") == nil {
+					cited["iam-role-trust-no-external-id"] = append(cited["iam-role-trust-no-external-id"], fmt.Sprintf("account %s in %s", account, label))
+				}
+			}
+			for _, p := range s.Principal["Federated"] {
+				_, provider, ok := strings.Cut(p, ":oidc-provider/")
+				if !ok {
+					continue
+				}
+				if !restrictsSub(s.conditionValues(provider + ":sub")) {
+					cited["iam-role-trust-oidc-no-sub"] = append(cited["iam-role-trust-oidc-no-sub"], fmt.Sprintf("%s in %s", provider, label))
+				}
+			}
+		}
+
+		resourceID := "role/" + name
+		for _, c := range roleTrustChecks {
+			if len(cited[c.id]) == 0 {
+				check.Pass(ctx, c.id, resourceID, fmt.Sprintf("Role %q does not trust %s", name, c.trusts))
+				continue
+			}
+			results = append(results, reporter.CheckResult{
+				CheckName:      c.id,
+				Severity:       c.severity,
+				ResourceID:     resourceID,
+				Message:        fmt.Sprintf("Role %q trusts %s: %s", name, c.trusts, strings.Join(cited[c.id], "; ")),
+				Recommendation: c.recommendation,
+			})
+		}
+	}
+	return results, nil
+}
+
+// roleTrustChecks are the checks of CheckRoleTrust.
+var roleTrustChecks = []struct {
+	id             string
+	severity       string
+	trusts         string
+	recommendation string
+}{
+	{"iam-role-trust-wildcard", "CRITICAL", "any AWS principal without conditions",
+		"Replace Principal \"*\" with the accounts or roles that need to assume the role"},
+	{"iam-role-trust-external-account", "HIGH", "accounts outside aws.trusted_accounts",
+		"Remove the account from the trust policy, or add it to aws.trusted_accounts if it is trusted"},
+	{"iam-role-trust-no-external-id", "HIGH", "external accounts without an sts:ExternalId condition",
+		"Require the external ID agreed with the third party in an sts:ExternalId condition"},
+	{"iam-role-trust-oidc-no-sub", "HIGH", "an OIDC provider without a sub condition",
+		"Restrict the token's sub claim, e.g. to repo:<org>/<repo>:ref:refs/heads/main for GitHub Actions"},
+}
+
+// principalAccount returns the account of an AWS principal in a trust
+// policy: an account ID, or the account of an ARN. Unique IDs, left when a
+// trusted principal is deleted, have none.
+func principalAccount(principal string) string {
+	if isAccountID(principal) {
+		return principal
+	}
+	if strings.HasPrefix(principal, "arn:") {
+		return accountOf(principal)
+	}
+	return ""
+}
+
+// isAccountID reports whether s is a 12-digit account ID.
+func isAccountID(s string) bool {
+	if len(s) != 12 {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionValues returns the values the statement's conditions require of
+// key, under any operator, or nil when no condition uses key. Condition keys
+// are matched regardless of case.
+func (s PolicyStatement) conditionValues(key string) []string {
+	var values []string
+	found := false
+	for _, conditions := range s.Condition {
+		for k, raw := range conditions {
+			if !strings.EqualFold(k, key) {
+				continue
+			}
+			found = true
+			var l stringList
+			if err := json.Unmarshal(raw, &l); err == nil {
+				values = append(values, l...)
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return append([]string{}, values...)
+}
+
+// restrictsSub reports whether sub condition values restrict the token's
+// subject, rather than being missing or a bare wildcard.
+func restrictsSub(values []string) bool {
+	for _, v := range values {
+		if strings.Trim(v, "*") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckUnusedRoles checks for roles that have not been used in more than
+// inactiveDays days, or never and are older than that. Service-linked roles
+// are left out.
+// Severity: LOW
+func CheckUnusedRoles(ctx context.Context, roles []iamtypes.RoleDetail, inactiveDays int) ([]reporter.CheckResult, error) {
+	var results []reporter.CheckResult
+	now := time.Now()
+	for _, r := range roles {
+		if aws.ToString(r.Path) == serviceRolePath {
+			continue
+		}
+		name := aws.ToString(r.RoleName)
+		var msg string
+		var days int
+		if r.RoleLastUsed != nil && r.RoleLastUsed.LastUsedDate != nil {
+			days = int(now.Sub(*r.RoleLastUsed.LastUsedDate).Hours() / 24)
+			msg = fmt.Sprintf("Role %q was last used %d days ago", name, days)
+		} else {
+			if r.CreateDate == nil {
+				continue
+			}
+			days = int(now.Sub(*r.CreateDate).Hours() / 24)
+			msg = fmt.Sprintf("Role %q has not been used since it was created %d days ago", name, days)
+		}
+		if days <= inactiveDays {
+			check.Pass(ctx, "iam-role-unused", "role/"+name, msg)
+			continue
+		}
+		results = append(results, reporter.CheckResult{
+			CheckName:      "iam-role-unused",
+			Severity:       "LOW",
+			ResourceID:     "role/" + name,
+			Message:        msg,
+			Recommendation: "Delete roles that are no longer used",
+		})
+	}
+	return results, nil
+}
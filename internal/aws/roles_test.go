@@ -0,0 +1,96 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+func trustRole(name, statements string) iamtypes.RoleDetail {
+	return iamtypes.RoleDetail{
+		RoleName:                 aws.String(name),
+		Arn:                      aws.String("arn:aws:iam::123456789012:role/" + name),
+		Path:                     aws.String("/"),
+		AssumeRolePolicyDocument: policyDoc(statements),
+	}
+}
+
+func TestCheckRoleTrust(t *testing.T) {
+	roles := []iamtypes.RoleDetail{
+		trustRole("open", `[{"Effect":"Allow","Principal":"*","Action":"sts:AssumeRole"},
+			{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"sts:AssumeRole","Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-abc"}}}]`),
+		trustRole("vendor", `{"Sid":"Vendor","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::999999999999:root"},"Action":"sts:AssumeRole"}`),
+		trustRole("vendor-with-id", `{"Effect":"Allow","Principal":{"AWS":"999999999999"},"Action":"sts:AssumeRole",
+			"Condition":{"StringEquals":{"sts:ExternalId":"abc123"}}}`),
+		trustRole("internal", `{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root","arn:aws:iam::111111111111:role/ci","AROAEXAMPLEID"]},"Action":"sts:AssumeRole"}`),
+		trustRole("github-any", `{"Effect":"Allow","Principal":{"Federated":"arn:aws:iam::123456789012:oidc-provider/token.actions.githubusercontent.com"},
+			"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringLike":{"token.actions.githubusercontent.com:sub":"*"}}}`),
+		trustRole("github-main", `{"Effect":"Allow","Principal":{"Federated":"arn:aws:iam::123456789012:oidc-provider/token.actions.githubusercontent.com"},
+			"Action":"sts:AssumeRoleWithWebIdentity","Condition":{"StringEquals":{"token.actions.githubusercontent.com:sub":"repo:acme/app:ref:refs/heads/main"}}}`),
+		trustRole("service", `{"Effect":"Allow","Principal":{"Service":"lambda.amazonaws.com"},"Action":"sts:AssumeRole"}`),
+	}
+	linked := trustRole("AWSServiceRoleForSupport", `{"Effect":"Allow","Principal":"*","Action":"sts:AssumeRole"}`)
+	linked.Path = aws.String(serviceRolePath)
+	roles = append(roles, linked)
+
+	results, err := CheckRoleTrust(context.Background(), roles, []string{"111111111111"})
+	if err != nil {
+		t.Fatalf("CheckRoleTrust() error = %v", err)
+	}
+	got := make(map[string]string)
+	for _, r := range results {
+		got[r.CheckName+" "+r.ResourceID] = r.Message
+	}
+	want := map[string]string{
+		"iam-role-trust-wildcard role/open":                   `"*" in statement 1`,
+		"iam-role-trust-external-account role/vendor":         `account 999999999999 in statement "Vendor"`,
+		"iam-role-trust-no-external-id role/vendor":           `account 999999999999 in statement "Vendor"`,
+		"iam-role-trust-external-account role/vendor-with-id": "account 999999999999 in statement 1",
+		"iam-role-trust-oidc-no-sub role/github-any":          "token.actions.githubusercontent.com in statement 1",
+	}
+	if len(got) != len(want) {
+		t.Errorf("findings = %v, want %d", got, len(want))
+	}
+	for key, cite := range want {
+		if !strings.HasSuffix(got[key], cite) {
+			t.Errorf("%s: message = %q, want it to cite %s", key, got[key], cite)
+		}
+	}
+}
+
+func TestCheckUnusedRoles(t *testing.T) {
+	now := time.Now()
+	role := func(name string, created time.Time, lastUsed *time.Time) iamtypes.RoleDetail {
+		return iamtypes.RoleDetail{
+			RoleName:     aws.String(name),
+			Path:         aws.String("/"),
+			CreateDate:   aws.Time(created),
+			RoleLastUsed: &iamtypes.RoleLastUsed{LastUsedDate: lastUsed},
+		}
+	}
+	old := now.AddDate(-1, 0, 0)
+	linked := role("AWSServiceRoleForSupport", old, nil)
+	linked.Path = aws.String(serviceRolePath)
+	roles := []iamtypes.RoleDetail{
+		role("active", old, aws.Time(now.AddDate(0, 0, -3))),
+		role("stale", old, aws.Time(now.AddDate(0, 0, -120))),
+		role("never", old, nil),
+		role("new", now.AddDate(0, 0, -2), nil),
+		linked,
+	}
+
+	results, err := CheckUnusedRoles(context.Background(), roles, 90)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].ResourceID != "role/stale" || results[1].ResourceID != "role/never" {
+		t.Fatalf("results = %+v, want role/stale and role/never", results)
+	}
+	if results[0].Severity != "LOW" || !strings.Contains(results[1].Message, "has not been used since it was created") {
+		t.Errorf("results = %+v", results)
+	}
+}
@@ -2,42 +2,163 @@ package aws
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
-// RunAll executes all AWS checks and returns aggregated results.
+// Checks returns every AWS check, configured by cfg.
+func Checks(cfg appconfig.AWSConfig) []check.Check[*AWSClients] {
+	return []check.Check[*AWSClients]{
+		check.New("iam-mfa-disabled", func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+			return CheckIAMUsersMFA(ctx, c.IAM)
+		}),
+		check.New("iam-old-access-key", func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+			return CheckIAMAccessKeyAge(ctx, c.IAM, cfg.KeyAgeDays)
+		}),
+		check.New("iam-admin-access", func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+			return CheckIAMAdminUsers(ctx, c.IAM)
+		}),
+		check.New("iam-root-mfa-disabled", credentialReportCheck(CheckRootMFA)),
+		check.New("iam-root-access-key", credentialReportCheck(CheckRootAccessKeys)),
+		check.New("iam-root-recently-used", credentialReportCheck(func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckRootUsage(ctx, r, cfg.RootUsageDays)
+		})),
+		check.New("iam-inactive-console-user", credentialReportCheck(func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckInactiveConsoleUsers(ctx, r, cfg.InactiveDays)
+		})),
+		check.New("iam-old-password", credentialReportCheck(func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckPasswordAge(ctx, r, cfg.PasswordAgeDays)
+		})),
+		check.New("iam-unused-access-key", credentialReportCheck(func(ctx context.Context, r *CredentialReport) ([]reporter.CheckResult, error) {
+			return CheckUnusedAccessKeys(ctx, r, cfg.InactiveDays)
+		})),
+		check.New("iam-policy-full-admin", iamPolicyCheck("iam-policy-full-admin")),
+		check.New("iam-policy-iam-wildcard", iamPolicyCheck("iam-policy-iam-wildcard")),
+		check.New("iam-policy-wildcard-resource", iamPolicyCheck("iam-policy-wildcard-resource")),
+		check.New("iam-policy-not-action", iamPolicyCheck("iam-policy-not-action")),
+		check.New("iam-privilege-escalation", func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+			details, err := c.AuthorizationDetails(ctx)
+			if err != nil {
+				return nil, err
+			}
+			principals, err := details.Principals()
+			if err != nil {
+				return nil, err
+			}
+			return CheckPrivilegeEscalation(ctx, principals), nil
+		}),
+		check.New("iam-role-trust-wildcard", roleTrustCheck("iam-role-trust-wildcard", cfg)),
+		check.New("iam-role-trust-external-account", roleTrustCheck("iam-role-trust-external-account", cfg)),
+		check.New("iam-role-trust-no-external-id", roleTrustCheck("iam-role-trust-no-external-id", cfg)),
+		check.New("iam-role-trust-oidc-no-sub", roleTrustCheck("iam-role-trust-oidc-no-sub", cfg)),
+		check.New("iam-role-unused", func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+			details, err := c.AuthorizationDetails(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return CheckUnusedRoles(ctx, details.Roles, cfg.InactiveDays)
+		}),
+		check.New("s3-public-bucket", func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+			return CheckS3PublicBuckets(ctx, c.S3)
+		}),
+		check.New("s3-no-encryption", func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+			return CheckS3Encryption(ctx, c.S3)
+		}),
+		check.New("s3-versioning-disabled", func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+			return CheckS3Versioning(ctx, c.S3)
+		}),
+		check.New("sg-all-ports-open", securityGroupCheck("sg-all-ports-open")),
+		check.New("sg-ssh-open", securityGroupCheck("sg-ssh-open")),
+		check.New("ebs-unencrypted", regional(CheckEBSEncryption)),
+		check.New("ebs-unattached", regional(CheckEBSUnattached)),
+	}
+}
+
+// RunAll executes all AWS checks and returns their outcomes.
 // Checks that fail due to insufficient permissions are skipped, not fatal.
-func RunAll(ctx context.Context, clients *AWSClients, cfg appconfig.AWSConfig) ([]reporter.CheckResult, error) {
-	var all []reporter.CheckResult
-	var errs []string
-
-	type checkFn func() ([]reporter.CheckResult, error)
-	checks := []checkFn{
-		func() ([]reporter.CheckResult, error) { return CheckIAMUsersMFA(ctx, clients.IAM) },
-		func() ([]reporter.CheckResult, error) { return CheckIAMAccessKeyAge(ctx, clients.IAM, cfg.KeyAgeDays) },
-		func() ([]reporter.CheckResult, error) { return CheckIAMAdminUsers(ctx, clients.IAM) },
-		func() ([]reporter.CheckResult, error) { return CheckS3PublicBuckets(ctx, clients.S3) },
-		func() ([]reporter.CheckResult, error) { return CheckS3Encryption(ctx, clients.S3) },
-		func() ([]reporter.CheckResult, error) { return CheckS3Versioning(ctx, clients.S3) },
-		func() ([]reporter.CheckResult, error) { return CheckSecurityGroups(ctx, clients.EC2) },
-		func() ([]reporter.CheckResult, error) { return CheckEBSEncryption(ctx, clients.EC2) },
-		func() ([]reporter.CheckResult, error) { return CheckEBSUnattached(ctx, clients.EC2) },
+func RunAll(ctx context.Context, clients *AWSClients, cfg appconfig.AWSConfig, opts check.Options) check.Outcomes {
+	return check.Run(ctx, clients, Checks(cfg), opts)
+}
+
+// securityGroupCheck evaluates the security groups of every region and keeps
+// only the findings of id. The groups of each region are described once and
+// shared by both security group checks.
+func securityGroupCheck(id string) check.Func[*AWSClients] {
+	return func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+		scan := regional(func(ctx context.Context, client EC2Client, region string) ([]reporter.CheckResult, error) {
+			groups, err := c.SecurityGroups(ctx, client, region)
+			if err != nil {
+				return nil, err
+			}
+			return evaluateSecurityGroups(ctx, groups, region), nil
+		})
+		results, err := scan(ctx, c)
+		var kept []reporter.CheckResult
+		for _, r := range results {
+			if r.CheckName == id {
+				kept = append(kept, r)
+			}
+		}
+		return kept, err
+	}
+}
+
+// credentialReportCheck runs fn on the account's credential report, which is
+// generated once and shared by every check built on it.
+func credentialReportCheck(fn func(context.Context, *CredentialReport) ([]reporter.CheckResult, error)) check.Func[*AWSClients] {
+	return func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+		report, err := c.CredentialReport(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, report)
 	}
+}
 
-	for _, check := range checks {
-		results, err := check()
+// iamPolicyCheck runs CheckPolicies, which analyzes every policy once for
+// all policy checks, and keeps only the findings of id.
+func iamPolicyCheck(id string) check.Func[*AWSClients] {
+	return func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+		details, err := c.AuthorizationDetails(ctx)
 		if err != nil {
-			errs = append(errs, err.Error())
-			continue
+			return nil, err
+		}
+		policies, err := details.Policies()
+		if err != nil {
+			return nil, err
+		}
+		var kept []reporter.CheckResult
+		for _, r := range CheckPolicies(ctx, policies) {
+			if r.CheckName == id {
+				kept = append(kept, r)
+			}
 		}
-		all = append(all, results...)
+		return kept, nil
 	}
+}
 
-	if len(errs) > 0 {
-		return all, fmt.Errorf("some checks failed: %v", errs)
+// roleTrustCheck runs CheckRoleTrust, which audits every trust policy once
+// for all trust checks, and keeps only the findings of id. The accounts in
+// aws.accounts are trusted along with aws.trusted_accounts.
+func roleTrustCheck(id string, cfg appconfig.AWSConfig) check.Func[*AWSClients] {
+	trusted := append([]string(nil), cfg.TrustedAccounts...)
+	for _, a := range cfg.Accounts {
+		trusted = append(trusted, a.ID)
+	}
+	return func(ctx context.Context, c *AWSClients) ([]reporter.CheckResult, error) {
+		details, err := c.AuthorizationDetails(ctx)
+		if err != nil {
+			return nil, err
+		}
+		results, err := CheckRoleTrust(ctx, details.Roles, trusted)
+		var kept []reporter.CheckResult
+		for _, r := range results {
+			if r.CheckName == id {
+				kept = append(kept, r)
+			}
+		}
+		return kept, err
 	}
-	return all, nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
@@ -18,15 +19,19 @@ func CheckS3PublicBuckets(ctx context.Context, client S3Client) ([]reporter.Chec
 	bucketsOut, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		if isPermissionError(err) {
-			return results, nil
+			return nil, permissionSkip("s3:ListAllMyBuckets", err)
 		}
 		return nil, fmt.Errorf("ListBuckets: %w", err)
 	}
 
+	denied := denials{action: "s3:GetBucketAcl", noun: "buckets"}
 	for _, bucket := range bucketsOut.Buckets {
 		name := *bucket.Name
 		isPublic, err := isBucketPublic(ctx, client, name)
 		if err != nil {
+			if isPermissionError(err) {
+				denied.add(err)
+			}
 			continue
 		}
 		if isPublic {
@@ -37,8 +42,13 @@ func CheckS3PublicBuckets(ctx context.Context, client S3Client) ([]reporter.Chec
 				Message:        fmt.Sprintf("S3 bucket %q is publicly accessible", name),
 				Recommendation: "Enable S3 Block Public Access settings for the bucket and account",
 			})
+		} else {
+			check.Pass(ctx, "s3-public-bucket", name, fmt.Sprintf("S3 bucket %q is not publicly accessible", name))
 		}
 	}
+	if err := denied.finish(ctx, len(bucketsOut.Buckets)); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
@@ -50,16 +60,23 @@ func CheckS3Encryption(ctx context.Context, client S3Client) ([]reporter.CheckRe
 	bucketsOut, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		if isPermissionError(err) {
-			return results, nil
+			return nil, permissionSkip("s3:ListAllMyBuckets", err)
 		}
 		return nil, fmt.Errorf("ListBuckets: %w", err)
 	}
 
+	denied := denials{action: "s3:GetEncryptionConfiguration", noun: "buckets"}
 	for _, bucket := range bucketsOut.Buckets {
 		name := *bucket.Name
 		_, err := client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: &name})
-		if err != nil {
-			if isNoSuchBucket(err) || isPermissionError(err) {
+		if err == nil {
+			check.Pass(ctx, "s3-no-encryption", name, fmt.Sprintf("S3 bucket %q has server-side encryption configured", name))
+		} else {
+			if isPermissionError(err) {
+				denied.add(err)
+				continue
+			}
+			if isNoSuchBucket(err) {
 				continue
 			}
 			// NoSuchServerSideEncryptionConfiguration means no encryption configured
@@ -72,6 +89,9 @@ func CheckS3Encryption(ctx context.Context, client S3Client) ([]reporter.CheckRe
 			})
 		}
 	}
+	if err := denied.finish(ctx, len(bucketsOut.Buckets)); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
@@ -83,15 +103,19 @@ func CheckS3Versioning(ctx context.Context, client S3Client) ([]reporter.CheckRe
 	bucketsOut, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		if isPermissionError(err) {
-			return results, nil
+			return nil, permissionSkip("s3:ListAllMyBuckets", err)
 		}
 		return nil, fmt.Errorf("ListBuckets: %w", err)
 	}
 
+	denied := denials{action: "s3:GetBucketVersioning", noun: "buckets"}
 	for _, bucket := range bucketsOut.Buckets {
 		name := *bucket.Name
 		verOut, err := client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: &name})
 		if err != nil {
+			if isPermissionError(err) {
+				denied.add(err)
+			}
 			continue
 		}
 		if verOut.Status != s3types.BucketVersioningStatusEnabled {
@@ -102,8 +126,13 @@ func CheckS3Versioning(ctx context.Context, client S3Client) ([]reporter.CheckRe
 				Message:        fmt.Sprintf("S3 bucket %q does not have versioning enabled", name),
 				Recommendation: "Enable versioning for data protection and point-in-time recovery",
 			})
+		} else {
+			check.Pass(ctx, "s3-versioning-disabled", name, fmt.Sprintf("S3 bucket %q has versioning enabled", name))
 		}
 	}
+	if err := denied.finish(ctx, len(bucketsOut.Buckets)); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
@@ -2,12 +2,14 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/kaustuvbot/devopsctl/internal/check"
 )
 
 type mockS3Client struct {
@@ -135,3 +137,36 @@ func TestCheckS3Versioning_Enabled(t *testing.T) {
 		t.Errorf("expected no results for versioned bucket, got %d", len(results))
 	}
 }
+
+func TestCheckS3PublicBuckets_AllDenied(t *testing.T) {
+	mock := &mockS3Client{
+		listBucketsOutput: &s3.ListBucketsOutput{Buckets: []s3types.Bucket{
+			{Name: aws.String("a")}, {Name: aws.String("b")},
+		}},
+		getPublicAccessBlockErr: fmt.Errorf("AccessDenied"),
+		getBucketAclErr:         fmt.Errorf("AccessDenied: not authorized to perform s3:GetBucketAcl"),
+	}
+	results, err := CheckS3PublicBuckets(context.Background(), mock)
+	assertSkipped(t, err, "AccessDenied on s3:GetBucketAcl")
+	if len(results) != 0 {
+		t.Errorf("expected no results, got %d", len(results))
+	}
+}
+
+func TestCheckS3Versioning_ListDenied(t *testing.T) {
+	mock := &mockS3Client{listBucketsErr: fmt.Errorf("AccessDenied")}
+	_, err := CheckS3Versioning(context.Background(), mock)
+	assertSkipped(t, err, "AccessDenied on s3:ListAllMyBuckets")
+}
+
+// assertSkipped fails the test unless err skips the check with reason.
+func assertSkipped(t *testing.T, err error, reason string) {
+	t.Helper()
+	var skip *check.SkipError
+	if !errors.As(err, &skip) {
+		t.Fatalf("expected the check to be skipped, got %v", err)
+	}
+	if skip.Reason != reason {
+		t.Errorf("skip reason = %q, want %q", skip.Reason, reason)
+	}
+}
@@ -0,0 +1,125 @@
+// Package baseline snapshots known findings so that later runs can report
+// only what is new since the snapshot.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// FormatVersion is the current baseline file format version.
+const FormatVersion = 1
+
+// DefaultPath is where `baseline create` writes when no path is given.
+const DefaultPath = ".devopsctl-baseline.json"
+
+// Entry is a finding recorded in a baseline.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	Module      string `json:"module"`
+	reporter.CheckResult
+}
+
+// Baseline is a snapshot of findings keyed by fingerprint.
+type Baseline struct {
+	Version     int       `json:"version"`
+	ToolVersion string    `json:"tool_version,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	Findings    []Entry   `json:"findings"`
+
+	index map[string]Entry
+}
+
+// New creates a baseline from the findings in the given module reports.
+func New(reports []reporter.Report, toolVersion string) *Baseline {
+	b := &Baseline{
+		Version:     FormatVersion,
+		ToolVersion: toolVersion,
+		CreatedAt:   time.Now().UTC(),
+		Findings:    []Entry{},
+	}
+	for _, report := range reports {
+		for _, r := range report.Results {
+			b.Findings = append(b.Findings, Entry{
+				Fingerprint: r.Fingerprint(report.Module),
+				Module:      report.Module,
+				CheckResult: r,
+			})
+		}
+	}
+	// Sorted entries keep baseline files diff-friendly.
+	sort.SliceStable(b.Findings, func(i, j int) bool {
+		a, c := b.Findings[i], b.Findings[j]
+		if a.Module != c.Module {
+			return a.Module < c.Module
+		}
+		if a.CheckName != c.CheckName {
+			return a.CheckName < c.CheckName
+		}
+		return a.ResourceID < c.ResourceID
+	})
+	b.buildIndex()
+	return b
+}
+
+// Load reads a baseline file.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read baseline: %w", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("cannot parse baseline %s: %w", path, err)
+	}
+	if b.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s (want %d)", b.Version, path, FormatVersion)
+	}
+	b.buildIndex()
+	return &b, nil
+}
+
+// Save writes the baseline to path as indented JSON.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("cannot write baseline: %w", err)
+	}
+	return nil
+}
+
+// Compare splits a module's results against the baseline. It returns the
+// findings that are not in the baseline and the baseline findings for the
+// module that no longer occur. Resolved findings are only meaningful when
+// results come from a complete run of the module.
+func (b *Baseline) Compare(module string, results []reporter.CheckResult) (newResults, resolved []reporter.CheckResult) {
+	seen := make(map[string]bool, len(results))
+	for _, r := range results {
+		fp := r.Fingerprint(module)
+		seen[fp] = true
+		if _, ok := b.index[fp]; !ok {
+			newResults = append(newResults, r)
+		}
+	}
+	for _, e := range b.Findings {
+		if e.Module == module && !seen[e.Fingerprint] {
+			resolved = append(resolved, e.CheckResult)
+		}
+	}
+	return newResults, resolved
+}
+
+func (b *Baseline) buildIndex() {
+	b.index = make(map[string]Entry, len(b.Findings))
+	for _, e := range b.Findings {
+		b.index[e.Fingerprint] = e
+	}
+}
@@ -0,0 +1,101 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+func TestCompare(t *testing.T) {
+	b := New([]reporter.Report{
+		{Module: "aws", Results: []reporter.CheckResult{
+			{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "legacy-bucket"},
+			{CheckName: "iam-mfa-disabled", Severity: "HIGH", ResourceID: "bob"},
+		}},
+		{Module: "git", Results: []reporter.CheckResult{
+			{CheckName: "git-stale-branch", Severity: "LOW", ResourceID: "old-feature"},
+		}},
+	}, "test")
+
+	current := []reporter.CheckResult{
+		// Severity and message changes do not make a finding new.
+		{CheckName: "s3-public-bucket", Severity: "HIGH", ResourceID: "legacy-bucket", Message: "reworded"},
+		{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "new-bucket"},
+	}
+	newResults, resolved := b.Compare("aws", current)
+
+	if len(newResults) != 1 || newResults[0].ResourceID != "new-bucket" {
+		t.Errorf("new = %+v, want only new-bucket", newResults)
+	}
+	if len(resolved) != 1 || resolved[0].ResourceID != "bob" {
+		t.Errorf("resolved = %+v, want only bob (other modules excluded)", resolved)
+	}
+}
+
+func TestCompareSameResourceInOtherModule(t *testing.T) {
+	b := New([]reporter.Report{
+		{Module: "docker", Results: []reporter.CheckResult{{CheckName: "c", ResourceID: "r"}}},
+	}, "test")
+
+	newResults, _ := b.Compare("git", []reporter.CheckResult{{CheckName: "c", ResourceID: "r"}})
+	if len(newResults) != 1 {
+		t.Error("fingerprint should include the module")
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	b := New([]reporter.Report{
+		{Module: "git", Results: []reporter.CheckResult{
+			{CheckName: "git-stale-branch", Severity: "LOW", ResourceID: "z-branch"},
+			{CheckName: "git-large-file", Severity: "MEDIUM", ResourceID: "big.bin"},
+		}},
+	}, "1.0.0")
+	if err := b.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.ToolVersion != "1.0.0" || len(loaded.Findings) != 2 {
+		t.Fatalf("loaded = %+v", loaded)
+	}
+	if loaded.Findings[0].CheckName != "git-large-file" {
+		t.Errorf("findings should be sorted by check, got %s first", loaded.Findings[0].CheckName)
+	}
+
+	newResults, resolved := loaded.Compare("git", []reporter.CheckResult{
+		{CheckName: "git-large-file", Severity: "MEDIUM", ResourceID: "big.bin"},
+	})
+	if len(newResults) != 0 || len(resolved) != 1 {
+		t.Errorf("after load: new = %d, resolved = %d, want 0 and 1", len(newResults), len(resolved))
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(bad); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+
+	future := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(future, []byte(`{"version": 99, "findings": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(future); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
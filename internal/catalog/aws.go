@@ -0,0 +1,341 @@
+package catalog
+
+import "github.com/kaustuvbot/devopsctl/internal/severity"
+
+func init() {
+	register(
+		Check{
+			ID:          "iam-mfa-disabled",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "IAM user without MFA",
+			Description: "Reports IAM users that have no MFA device enabled.",
+			Rationale:   "A leaked password alone is enough to sign in as a user without MFA.",
+			Remediation: []string{
+				"Enable a virtual or hardware MFA device for the user in the IAM console.",
+				"Require MFA for console access with an IAM policy condition on aws:MultiFactorAuthPresent.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_mfa.html"},
+		},
+		Check{
+			ID:          "iam-old-access-key",
+			Module:      "aws",
+			Severity:    severity.Medium,
+			Title:       "Old IAM access key",
+			Description: "Reports active access keys older than aws.key_age_days (default 90). Keys older than 120 days are reported as HIGH.",
+			Rationale:   "Long-lived keys are more likely to have leaked and give an attacker lasting access.",
+			Remediation: []string{
+				"Create a new access key and move its consumers over.",
+				"Deactivate the old key, then delete it once nothing uses it.",
+				"Prefer IAM roles and short-lived credentials over user access keys.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_access-keys.html"},
+		},
+		Check{
+			ID:          "iam-admin-access",
+			Module:      "aws",
+			Severity:    severity.Critical,
+			Title:       "IAM user with AdministratorAccess",
+			Description: "Reports IAM users with the AdministratorAccess managed policy, attached directly or through a group.",
+			Rationale:   "Compromise of any such user compromises the whole account.",
+			Remediation: []string{
+				"Replace AdministratorAccess with policies scoped to what the user needs.",
+				"Grant administrative access through a role assumed with MFA instead of a user.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/best-practices.html"},
+		},
+		Check{
+			ID:          "iam-root-mfa-disabled",
+			Module:      "aws",
+			Severity:    severity.Critical,
+			Title:       "Root account without MFA",
+			Description: "Reports a root account without an MFA device, from the IAM credential report.",
+			Rationale:   "The root account has unrestricted access to the account and its billing, and cannot be limited by IAM policies.",
+			Remediation: []string{
+				"Enable a hardware or virtual MFA device for the root account.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/enable-mfa-for-root.html"},
+		},
+		Check{
+			ID:          "iam-root-access-key",
+			Module:      "aws",
+			Severity:    severity.Critical,
+			Title:       "Root account access key",
+			Description: "Reports active access keys of the root account, from the IAM credential report.",
+			Rationale:   "A leaked root access key gives full, unrestricted programmatic access to the account.",
+			Remediation: []string{
+				"Delete the root account's access keys.",
+				"Move their consumers to IAM roles or to users with scoped policies.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/id_root-user_manage_add-key.html"},
+		},
+		Check{
+			ID:          "iam-root-recently-used",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "Root account used recently",
+			Description: "Reports a root account that signed in or used an access key within aws.root_usage_days (default 30), from the IAM credential report.",
+			Rationale:   "Routine use of the root account exposes its credentials to far more risk than the few tasks that require it.",
+			Remediation: []string{
+				"Find who used the root account in CloudTrail and move that work to IAM roles.",
+				"Reserve the root account for the tasks that require it.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/root-user-tasks.html"},
+		},
+		Check{
+			ID:          "iam-inactive-console-user",
+			Module:      "aws",
+			Severity:    severity.Medium,
+			Title:       "Inactive IAM console user",
+			Description: "Reports IAM users with a console password who have not signed in for more than aws.inactive_days (default 90), or never since they were created.",
+			Rationale:   "Passwords of users who no longer sign in go unnoticed when they leak.",
+			Remediation: []string{
+				"Remove the user's console password, or delete the user if it is no longer needed.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_finding-unused.html"},
+		},
+		Check{
+			ID:          "iam-old-password",
+			Module:      "aws",
+			Severity:    severity.Medium,
+			Title:       "Old IAM console password",
+			Description: "Reports console passwords not changed for more than aws.password_age_days (default 90).",
+			Rationale:   "Old passwords are more likely to have been reused elsewhere or leaked.",
+			Remediation: []string{
+				"Have the user change the password.",
+				"Set a maximum password age in the account password policy.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_passwords_account-policy.html"},
+		},
+		Check{
+			ID:          "iam-unused-access-key",
+			Module:      "aws",
+			Severity:    severity.Medium,
+			Title:       "Unused IAM access key",
+			Description: "Reports active access keys not used for more than aws.inactive_days (default 90), or never used and older than that.",
+			Rationale:   "Keys nobody uses only add ways into the account.",
+			Remediation: []string{
+				"Deactivate the key, then delete it once nothing breaks.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_finding-unused.html"},
+		},
+		Check{
+			ID:          "iam-policy-full-admin",
+			Module:      "aws",
+			Severity:    severity.Critical,
+			Title:       "IAM policy granting full administrative access",
+			Description: "Reports inline and attached managed policies of users, groups and roles with an Allow statement for action \"*\" on resource \"*\", citing the statement.",
+			Rationale:   "Unlike iam-admin-access, this catches customer-managed and inline policies equivalent to AdministratorAccess, and roles that have them.",
+			Remediation: []string{
+				"Replace the statement with the actions and resources the principals need.",
+				"Grant administrative access through a dedicated role assumed with MFA.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/best-practices.html#grant-least-privilege"},
+		},
+		Check{
+			ID:          "iam-policy-iam-wildcard",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "IAM policy granting iam:*",
+			Description: "Reports policies with an Allow statement for iam:*, citing the statement.",
+			Rationale:   "A principal with every IAM action can attach any policy to itself and become an administrator.",
+			Remediation: []string{
+				"Grant only the IAM actions the principals need, on the resources they manage.",
+				"Use permissions boundaries for principals that must create roles or users.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_boundaries.html"},
+		},
+		Check{
+			ID:          "iam-policy-wildcard-resource",
+			Module:      "aws",
+			Severity:    severity.Medium,
+			Title:       "IAM policy granting a sensitive service on every resource",
+			Description: "Reports policies with an Allow statement for every action of a sensitive service, such as s3:* or kms:*, on resource \"*\", citing the statement.",
+			Rationale:   "Full access to services such as S3, KMS, Secrets Manager or STS on every resource exposes all of the account's data and keys.",
+			Remediation: []string{
+				"Scope the statement to the ARNs of the resources the principals use.",
+				"List the actions the principals need instead of a service-wide wildcard.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/best-practices.html#grant-least-privilege"},
+		},
+		Check{
+			ID:          "iam-policy-not-action",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "IAM policy allowing with NotAction",
+			Description: "Reports policies with an Allow statement that uses NotAction, citing the statement.",
+			Rationale:   "Allow with NotAction grants every action except those listed, including actions of services added later.",
+			Remediation: []string{
+				"Replace NotAction with an explicit list of allowed actions.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_notaction.html"},
+		},
+		Check{
+			ID:          "iam-privilege-escalation",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "IAM privilege-escalation path",
+			Description: "Reports users and roles, other than administrators, whose combined policies allow a known privilege-escalation method, such as iam:PassRole with lambda:CreateFunction or iam:CreatePolicyVersion, or that can assume a chain of roles leading to an administrator or to such a method. The finding explains each path step by step.",
+			Rationale:   "A principal that can grant itself more permissions effectively already has them, whatever its policies appear to allow.",
+			Remediation: []string{
+				"Remove the permissions of each reported path the principal does not need.",
+				"Restrict iam:PassRole and IAM write actions to specific resources.",
+				"Attach a permissions boundary to principals that must manage IAM.",
+			},
+			References: []string{
+				"https://rhinosecuritylabs.com/aws/aws-privilege-escalation-methods-mitigation/",
+				"https://docs.aws.amazon.com/IAM/latest/UserGuide/access_policies_boundaries.html",
+			},
+		},
+		Check{
+			ID:          "iam-role-trust-wildcard",
+			Module:      "aws",
+			Severity:    severity.Critical,
+			Title:       "Role trusting any AWS principal",
+			Description: "Reports roles whose trust policy allows Principal \"*\" without conditions, citing the statement.",
+			Rationale:   "Any AWS account in the world can assume such a role.",
+			Remediation: []string{
+				"Replace \"*\" with the accounts or roles that need to assume the role.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_principal.html"},
+		},
+		Check{
+			ID:          "iam-role-trust-external-account",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "Role trusting an unknown account",
+			Description: "Reports roles whose trust policy allows an account other than the role's own, those in aws.accounts and those in aws.trusted_accounts, citing the statement.",
+			Rationale:   "Every principal of a trusted account that is allowed sts:AssumeRole can use the role; trust in accounts nobody vouches for is often left over from old integrations.",
+			Remediation: []string{
+				"Remove the account from the trust policy if it no longer needs the role.",
+				"Add the account to aws.trusted_accounts once it is reviewed.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/id_roles_common-scenarios_third-party.html"},
+		},
+		Check{
+			ID:          "iam-role-trust-no-external-id",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "Third-party role trust without ExternalId",
+			Description: "Reports roles trusting an account outside aws.trusted_accounts without an sts:ExternalId condition, citing the statement.",
+			Rationale:   "Without an external ID, another customer of the same third party can trick it into using your role (the confused deputy problem).",
+			Remediation: []string{
+				"Add an sts:ExternalId condition with the external ID the third party gives you.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/confused-deputy.html"},
+		},
+		Check{
+			ID:          "iam-role-trust-oidc-no-sub",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "OIDC role trust without a sub condition",
+			Description: "Reports roles trusting an OIDC provider, such as GitHub Actions, without a condition restricting the token's sub claim, citing the statement.",
+			Rationale:   "Without a sub condition, any workload the provider issues tokens for, such as any GitHub repository, can assume the role.",
+			Remediation: []string{
+				"Add a StringEquals or StringLike condition on <provider>:sub, e.g. token.actions.githubusercontent.com:sub set to repo:<org>/<repo>:ref:refs/heads/main.",
+			},
+			References: []string{"https://docs.github.com/en/actions/security-for-github-actions/security-hardening-your-deployments/configuring-openid-connect-in-amazon-web-services"},
+		},
+		Check{
+			ID:          "iam-role-unused",
+			Module:      "aws",
+			Severity:    severity.Low,
+			Title:       "Unused IAM role",
+			Description: "Reports roles not used for more than aws.inactive_days (default 90), or never used and older than that. Service-linked roles are ignored.",
+			Rationale:   "Unused roles keep their permissions and trust, and are rarely reviewed.",
+			Remediation: []string{
+				"Check the role's last activity in the IAM console, then delete it.",
+			},
+			References: []string{"https://docs.aws.amazon.com/IAM/latest/UserGuide/id_roles_manage_delete.html"},
+		},
+		Check{
+			ID:          "s3-public-bucket",
+			Module:      "aws",
+			Severity:    severity.Critical,
+			Title:       "Public S3 bucket",
+			Description: "Reports buckets whose ACL or policy makes them publicly accessible.",
+			Rationale:   "Public buckets are a common cause of data leaks.",
+			Remediation: []string{
+				"Enable S3 Block Public Access on the bucket.",
+				"Enable S3 Block Public Access at the account level unless public buckets are required.",
+				"Serve intentionally public content through CloudFront instead of a public bucket.",
+			},
+			References: []string{"https://docs.aws.amazon.com/AmazonS3/latest/userguide/access-control-block-public-access.html"},
+		},
+		Check{
+			ID:          "s3-no-encryption",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "S3 bucket without default encryption",
+			Description: "Reports buckets with no server-side encryption configuration.",
+			Rationale:   "Default encryption protects objects at rest, including ones uploaded without encryption headers.",
+			Remediation: []string{
+				"Configure SSE-S3 or SSE-KMS as the bucket's default encryption.",
+			},
+			References: []string{"https://docs.aws.amazon.com/AmazonS3/latest/userguide/serv-side-encryption.html"},
+		},
+		Check{
+			ID:          "s3-versioning-disabled",
+			Module:      "aws",
+			Severity:    severity.Low,
+			Title:       "S3 bucket without versioning",
+			Description: "Reports buckets where versioning is not enabled.",
+			Rationale:   "Without versioning, overwritten or deleted objects cannot be recovered.",
+			Remediation: []string{
+				"Enable versioning on the bucket.",
+				"Add a lifecycle rule that expires noncurrent versions to control cost.",
+			},
+			References: []string{"https://docs.aws.amazon.com/AmazonS3/latest/userguide/Versioning.html"},
+		},
+		Check{
+			ID:          "sg-all-ports-open",
+			Module:      "aws",
+			Severity:    severity.Critical,
+			Title:       "Security group open to the internet on all ports",
+			Description: "Reports security groups with an inbound all-traffic rule from 0.0.0.0/0.",
+			Rationale:   "Every listening service on attached instances is reachable from anywhere.",
+			Remediation: []string{
+				"Remove the all-traffic rule.",
+				"Allow only the ports the workload serves, from the CIDR ranges or security groups that need them.",
+			},
+			References: []string{"https://docs.aws.amazon.com/vpc/latest/userguide/vpc-security-groups.html"},
+		},
+		Check{
+			ID:          "sg-ssh-open",
+			Module:      "aws",
+			Severity:    severity.Critical,
+			Title:       "Security group open to the internet on SSH",
+			Description: "Reports security groups that allow port 22 from 0.0.0.0/0.",
+			Rationale:   "SSH exposed to the internet is continuously brute-forced and scanned for vulnerabilities.",
+			Remediation: []string{
+				"Restrict the rule to known IP ranges.",
+				"Use AWS Systems Manager Session Manager instead of inbound SSH.",
+			},
+			References: []string{"https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager.html"},
+		},
+		Check{
+			ID:          "ebs-unencrypted",
+			Module:      "aws",
+			Severity:    severity.High,
+			Title:       "Unencrypted EBS volume",
+			Description: "Reports EBS volumes that are not encrypted.",
+			Rationale:   "Unencrypted volumes and their snapshots expose data at rest.",
+			Remediation: []string{
+				"Enable EBS encryption by default for the account and region.",
+				"Migrate existing volumes by copying an encrypted snapshot and restoring from it.",
+			},
+			References: []string{"https://docs.aws.amazon.com/ebs/latest/userguide/ebs-encryption.html"},
+		},
+		Check{
+			ID:          "ebs-unattached",
+			Module:      "aws",
+			Severity:    severity.Low,
+			Title:       "Unattached EBS volume",
+			Description: "Reports EBS volumes that are not attached to any instance.",
+			Rationale:   "Unattached volumes keep costing money and may hold forgotten data.",
+			Remediation: []string{
+				"Snapshot the volume if its data may be needed, then delete it.",
+			},
+		},
+	)
+}
@@ -0,0 +1,96 @@
+// Package catalog is the central registry of every check devopsctl runs:
+// its ID, module, default severity and documentation.
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kaustuvbot/devopsctl/internal/severity"
+)
+
+// Check documents a single check.
+type Check struct {
+	ID          string         `json:"id"`
+	Module      string         `json:"module"`
+	Severity    severity.Level `json:"severity"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Rationale   string         `json:"rationale"`
+	Remediation []string       `json:"remediation"`
+	References  []string       `json:"references,omitempty"`
+}
+
+var checks = map[string]Check{}
+
+// register adds a check to the catalog. It panics on an incomplete entry or
+// a duplicate ID, which are programming errors caught by the tests.
+func register(cs ...Check) {
+	for _, c := range cs {
+		if c.ID == "" || c.Module == "" || !severity.IsValid(string(c.Severity)) {
+			panic(fmt.Sprintf("catalog: incomplete check %+v", c))
+		}
+		if _, dup := checks[c.ID]; dup {
+			panic(fmt.Sprintf("catalog: duplicate check %q", c.ID))
+		}
+		checks[c.ID] = c
+	}
+}
+
+// Lookup returns the check with the given ID.
+func Lookup(id string) (Check, bool) {
+	c, ok := checks[id]
+	return c, ok
+}
+
+// All returns every check, ordered by module and ID.
+func All() []Check {
+	out := make([]Check, 0, len(checks))
+	for _, c := range checks {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Module != out[j].Module {
+			return out[i].Module < out[j].Module
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// Match returns the checks whose ID matches pattern, in the order of All.
+// Patterns use the * and ? wildcards of Glob, as suppressions do; a pattern
+// without wildcards matches only that ID.
+func Match(pattern string) []Check {
+	re := Glob(pattern)
+	var out []Check
+	for _, c := range All() {
+		if re.MatchString(c.ID) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Glob compiles a shell-style pattern into a regexp matching the whole
+// string. Suppressions and severity overrides match check IDs and resource
+// IDs with it, and config validation matches check IDs the same way. "*" matches any run of characters, including "/" and ":", since
+// resource IDs are not file paths; "?" matches a single character.
+func Glob(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
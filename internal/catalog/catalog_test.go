@@ -0,0 +1,88 @@
+package catalog
+
+import "testing"
+
+func TestCatalogEntriesComplete(t *testing.T) {
+	modules := map[string]bool{"aws": true, "docker": true, "terraform": true, "git": true}
+	for _, c := range All() {
+		if !modules[c.Module] {
+			t.Errorf("%s: unknown module %q", c.ID, c.Module)
+		}
+		if c.Title == "" || c.Description == "" || c.Rationale == "" || len(c.Remediation) == 0 {
+			t.Errorf("%s: title, description, rationale and remediation are required", c.ID)
+		}
+	}
+}
+
+func TestAllOrder(t *testing.T) {
+	all := All()
+	if len(all) == 0 {
+		t.Fatal("catalog is empty")
+	}
+	for i := 1; i < len(all); i++ {
+		prev, cur := all[i-1], all[i]
+		if prev.Module > cur.Module || (prev.Module == cur.Module && prev.ID >= cur.ID) {
+			t.Errorf("%s/%s sorted before %s/%s", prev.Module, prev.ID, cur.Module, cur.ID)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	c, ok := Lookup("iam-mfa-disabled")
+	if !ok || c.Module != "aws" || c.Severity != "HIGH" {
+		t.Errorf("Lookup(iam-mfa-disabled) = %+v, %v", c, ok)
+	}
+	if _, ok := Lookup("no-such-check"); ok {
+		t.Error("Lookup should fail for unknown IDs")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    int
+	}{
+		{"sg-ssh-open", 1},
+		{"sg-*", 2},
+		{"s3-???-encryption", 0},
+		{"s3-no-encryptio?", 1},
+		{"k8s-*", 0},
+		{"[", 0},
+		// Brackets are literal, as in suppressions, not character classes.
+		{"iam-[a-z]*", 0},
+	}
+	for _, tt := range tests {
+		if got := len(Match(tt.pattern)); got != tt.want {
+			t.Errorf("Match(%q) returned %d checks, want %d", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestGlob(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"*", "anything/at:all", true},
+		{"prod-*", "prod-db", true},
+		{"prod-*", "dev-prod-db", false},
+		{"sg-????", "sg-1234", true},
+		{"sg-????", "sg-12345", false},
+		{"a.b", "aXb", false},
+		{"Dockerfile:line*", "Dockerfile:line12", true},
+	}
+	for _, tt := range tests {
+		if got := Glob(tt.pattern).MatchString(tt.s); got != tt.want {
+			t.Errorf("Glob(%q).MatchString(%q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on duplicate ID")
+		}
+	}()
+	register(Check{ID: "iam-mfa-disabled", Module: "aws", Severity: "HIGH"})
+}
@@ -0,0 +1,83 @@
+package catalog
+
+import "github.com/kaustuvbot/devopsctl/internal/severity"
+
+func init() {
+	register(
+		Check{
+			ID:          "dockerfile-latest-tag",
+			Module:      "docker",
+			Severity:    severity.Medium,
+			Title:       "Base image uses a mutable tag",
+			Description: "Reports FROM instructions with an untagged image or the :latest tag.",
+			Rationale:   "Mutable tags make builds unreproducible and can pull in unreviewed changes.",
+			Remediation: []string{
+				"Pin the base image to a specific version tag, e.g. ubuntu:22.04.",
+				"Pin to a digest for fully reproducible builds.",
+			},
+			References: []string{"https://docs.docker.com/build/building/best-practices/"},
+		},
+		Check{
+			ID:          "dockerfile-runs-as-root",
+			Module:      "docker",
+			Severity:    severity.High,
+			Title:       "Container runs as root",
+			Description: "Reports Dockerfiles without a USER instruction.",
+			Rationale:   "A process running as root makes a container escape far more damaging.",
+			Remediation: []string{
+				"Create an unprivileged user in the image.",
+				"Add a USER instruction, e.g. USER 1001, before the entrypoint.",
+			},
+			References: []string{"https://docs.docker.com/reference/dockerfile/#user"},
+		},
+		Check{
+			ID:          "dockerfile-no-healthcheck",
+			Module:      "docker",
+			Severity:    severity.Low,
+			Title:       "No HEALTHCHECK",
+			Description: "Reports Dockerfiles without a HEALTHCHECK instruction.",
+			Rationale:   "Without a health check, orchestrators cannot tell a hung container from a working one.",
+			Remediation: []string{
+				"Add a HEALTHCHECK that probes the service, e.g. an HTTP status endpoint.",
+			},
+			References: []string{"https://docs.docker.com/reference/dockerfile/#healthcheck"},
+		},
+		Check{
+			ID:          "dockerfile-no-multi-stage",
+			Module:      "docker",
+			Severity:    severity.Low,
+			Title:       "Single-stage build",
+			Description: "Reports Dockerfiles with only one FROM instruction.",
+			Rationale:   "Single-stage images usually ship compilers and build tools, which add size and attack surface.",
+			Remediation: []string{
+				"Build in one stage and copy only the artifacts into a minimal final stage.",
+			},
+			References: []string{"https://docs.docker.com/build/building/multi-stage/"},
+		},
+		Check{
+			ID:          "dockerfile-risky-expose",
+			Module:      "docker",
+			Severity:    severity.Medium,
+			Title:       "Sensitive port exposed",
+			Description: "Reports EXPOSE of SSH, Telnet, MySQL, PostgreSQL, Redis or MongoDB ports.",
+			Rationale:   "These services should rarely be reachable from outside the container network.",
+			Remediation: []string{
+				"Remove the EXPOSE unless the port must be published.",
+				"Keep databases on an internal network and never publish them to the host.",
+			},
+		},
+		Check{
+			ID:          "trivy-image-vuln",
+			Module:      "docker",
+			Severity:    severity.High,
+			Title:       "Vulnerable package in image",
+			Description: "Reports HIGH and CRITICAL vulnerabilities found by Trivy in the image given with --image. The severity is Trivy's.",
+			Rationale:   "Known vulnerabilities in shipped packages are the easiest way into a container.",
+			Remediation: []string{
+				"Update the affected package or the base image to a patched version.",
+				"Rebuild and rescan the image.",
+			},
+			References: []string{"https://aquasecurity.github.io/trivy/"},
+		},
+	)
+}
@@ -0,0 +1,46 @@
+package catalog
+
+import "github.com/kaustuvbot/devopsctl/internal/severity"
+
+func init() {
+	register(
+		Check{
+			ID:          "git-repo-size",
+			Module:      "git",
+			Severity:    severity.Medium,
+			Title:       "Large repository",
+			Description: "Reports repositories whose object store exceeds git.repo_size_mb (default 500).",
+			Rationale:   "Large repositories slow down clones and CI.",
+			Remediation: []string{
+				"Move large binaries to Git LFS.",
+				"Run git gc to pack loose objects.",
+			},
+			References: []string{"https://git-lfs.com/", "https://git-scm.com/docs/git-gc"},
+		},
+		Check{
+			ID:          "git-large-file",
+			Module:      "git",
+			Severity:    severity.Medium,
+			Title:       "Large tracked file",
+			Description: "Reports tracked files larger than git.large_file_mb (default 50).",
+			Rationale:   "Large files bloat every clone for good, even after they are deleted.",
+			Remediation: []string{
+				"Track the file with Git LFS or remove it from version control.",
+				"Rewrite history to drop it if clone size matters.",
+			},
+			References: []string{"https://git-lfs.com/"},
+		},
+		Check{
+			ID:          "git-stale-branch",
+			Module:      "git",
+			Severity:    severity.Low,
+			Title:       "Stale branch",
+			Description: "Reports branches with no commits in git.branch_age_days (default 90).",
+			Rationale:   "Stale branches clutter the repository and hide work that was never merged.",
+			Remediation: []string{
+				"Merge or delete the branch.",
+			},
+			References: []string{"https://git-scm.com/docs/git-branch"},
+		},
+	)
+}
@@ -0,0 +1,60 @@
+package catalog
+
+import "github.com/kaustuvbot/devopsctl/internal/severity"
+
+func init() {
+	register(
+		Check{
+			ID:          "terraform-fmt",
+			Module:      "terraform",
+			Severity:    severity.Medium,
+			Title:       "Unformatted Terraform files",
+			Description: "Reports directories where terraform fmt -check finds unformatted files. Needs the terraform binary.",
+			Rationale:   "Consistent formatting keeps diffs small and reviews focused on real changes.",
+			Remediation: []string{
+				"Run terraform fmt in the directory.",
+				"Run terraform fmt -check in CI.",
+			},
+			References: []string{"https://developer.hashicorp.com/terraform/cli/commands/fmt"},
+		},
+		Check{
+			ID:          "terraform-validate",
+			Module:      "terraform",
+			Severity:    severity.High,
+			Title:       "Invalid Terraform configuration",
+			Description: "Reports configurations rejected by terraform validate. Needs the terraform binary.",
+			Rationale:   "An invalid configuration fails at plan time, usually later in the pipeline.",
+			Remediation: []string{
+				"Run terraform validate and fix the reported errors.",
+			},
+			References: []string{"https://developer.hashicorp.com/terraform/cli/commands/validate"},
+		},
+		Check{
+			ID:          "provider-version",
+			Module:      "terraform",
+			Severity:    severity.Medium,
+			Title:       "Provider without version constraint",
+			Description: "Reports providers with no version constraint.",
+			Rationale:   "Unconstrained providers can be upgraded across major versions without warning.",
+			Remediation: []string{
+				"Declare each provider in required_providers with a version constraint, e.g. \"~> 5.0\".",
+				"Commit .terraform.lock.hcl.",
+			},
+			References: []string{"https://developer.hashicorp.com/terraform/language/providers/requirements"},
+		},
+		Check{
+			ID:          "hardcoded-credentials",
+			Module:      "terraform",
+			Severity:    severity.Critical,
+			Title:       "Hardcoded credentials",
+			Description: "Reports AWS keys, passwords, API keys and secrets written literally in .tf files.",
+			Rationale:   "Credentials in source control are exposed to everyone with access to the repository and its history.",
+			Remediation: []string{
+				"Revoke and rotate the exposed credential.",
+				"Read secrets from environment variables, a secrets manager or sensitive variables instead.",
+				"Remove the secret from git history if the repository is shared.",
+			},
+			References: []string{"https://developer.hashicorp.com/terraform/language/values/variables"},
+		},
+	)
+}
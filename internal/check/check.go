@@ -0,0 +1,72 @@
+// Package check defines the abstraction shared by every module's checks and
+// the runner that executes them.
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kaustuvbot/devopsctl/internal/catalog"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// Check is a single check run against a module's target, such as AWS
+// clients or a parsed Dockerfile.
+type Check[T any] interface {
+	// ID is the check's catalog ID, also used as CheckName in its results.
+	ID() string
+	// Metadata returns the check's catalog entry.
+	Metadata() catalog.Check
+	// Run evaluates target and returns its findings.
+	Run(ctx context.Context, target T) ([]reporter.CheckResult, error)
+}
+
+// Func is the signature of a function implementing a check.
+type Func[T any] func(ctx context.Context, target T) ([]reporter.CheckResult, error)
+
+type funcCheck[T any] struct {
+	meta catalog.Check
+	fn   Func[T]
+}
+
+// New returns a check implemented by fn. It panics if id is not in the
+// catalog, so every check that can run is documented.
+func New[T any](id string, fn Func[T]) Check[T] {
+	meta, ok := catalog.Lookup(id)
+	if !ok {
+		panic(fmt.Sprintf("check: %q is not in the catalog", id))
+	}
+	return &funcCheck[T]{meta: meta, fn: fn}
+}
+
+func (c *funcCheck[T]) ID() string              { return c.meta.ID }
+func (c *funcCheck[T]) Metadata() catalog.Check { return c.meta }
+
+func (c *funcCheck[T]) Run(ctx context.Context, target T) ([]reporter.CheckResult, error) {
+	return c.fn(ctx, target)
+}
+
+// SkipError signals that a check could not run against this target, for
+// example because a tool it needs is not installed.
+type SkipError struct {
+	Reason string
+}
+
+func (e *SkipError) Error() string {
+	return "skipped: " + e.Reason
+}
+
+// Skip returns an error that marks a check as skipped with the given reason.
+func Skip(reason string) error {
+	return &SkipError{Reason: reason}
+}
+
+// skipReason returns the reason if err is a SkipError.
+func skipReason(err error) (string, bool) {
+	var skip *SkipError
+	if errors.As(err, &skip) {
+		return skip.Reason, true
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package check
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+type recorderKey struct{}
+
+// recorder collects the resources the running check found compliant and
+// what it could not evaluate. A check may record from several goroutines.
+type recorder struct {
+	id      string
+	mu      sync.Mutex
+	passed  []reporter.Evidence
+	partial []string
+}
+
+// Pass records that resourceID passed check id. Checks call it for every
+// resource they evaluate without a finding, so reports can show what was
+// checked as well as what failed. Records for an ID other than the running
+// check's are dropped, which lets a function shared by several checks report
+// passes for all of them; outside Run, Pass does nothing.
+func Pass(ctx context.Context, id, resourceID, message string) {
+	rec, ok := ctx.Value(recorderKey{}).(*recorder)
+	if !ok || rec.id != id {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.passed = append(rec.passed, reporter.Evidence{ResourceID: resourceID, Message: message})
+}
+
+// Partial records that the running check left some resources unevaluated,
+// e.g. "3 of 7 buckets denied: AccessDenied on s3:GetBucketAcl". A check that
+// still passed or failed carries the notes in its coverage status, so the
+// resources it did not see are not mistaken for compliant ones. Outside Run,
+// Partial does nothing.
+func Partial(ctx context.Context, note string) {
+	rec, ok := ctx.Value(recorderKey{}).(*recorder)
+	if !ok {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.partial = append(rec.partial, note)
+}
+
+// evaluated counts the distinct resources with a finding or a pass record.
+func evaluated(findings []reporter.CheckResult, passed []reporter.Evidence) int {
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		seen[f.ResourceID] = true
+	}
+	for _, p := range passed {
+		seen[p.ResourceID] = true
+	}
+	return len(seen)
+}
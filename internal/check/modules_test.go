@@ -0,0 +1,49 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/kaustuvbot/devopsctl/internal/aws"
+	"github.com/kaustuvbot/devopsctl/internal/catalog"
+	"github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/docker"
+	"github.com/kaustuvbot/devopsctl/internal/git"
+	"github.com/kaustuvbot/devopsctl/internal/terraform"
+)
+
+// TestModulesMatchCatalog checks that every catalog entry has exactly one
+// runnable check in its module, and nothing runs that is not documented.
+func TestModulesMatchCatalog(t *testing.T) {
+	cfg := config.DefaultConfig()
+	ids := map[string][]string{}
+	for _, c := range aws.Checks(cfg.AWS) {
+		ids["aws"] = append(ids["aws"], c.ID())
+	}
+	for _, c := range docker.Checks() {
+		ids["docker"] = append(ids["docker"], c.ID())
+	}
+	for _, c := range git.Checks(cfg.Git) {
+		ids["git"] = append(ids["git"], c.ID())
+	}
+	for _, c := range terraform.Checks() {
+		ids["terraform"] = append(ids["terraform"], c.ID())
+	}
+
+	seen := map[string]bool{}
+	for module, list := range ids {
+		for _, id := range list {
+			if seen[id] {
+				t.Errorf("%s: check registered twice", id)
+			}
+			seen[id] = true
+			if c, _ := catalog.Lookup(id); c.Module != module {
+				t.Errorf("%s: runs in %s but the catalog lists module %q", id, module, c.Module)
+			}
+		}
+	}
+	for _, c := range catalog.All() {
+		if !seen[c.ID] {
+			t.Errorf("%s: in the catalog but no module runs it", c.ID)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// Status describes how a check run ended.
+type Status string
+
+const (
+	StatusPassed  Status = reporter.CheckPassed
+	StatusFailed  Status = reporter.CheckFailed
+	StatusSkipped Status = reporter.CheckSkipped
+	StatusErrored Status = reporter.CheckErrored
+)
+
+// Outcome is the result of running one check.
+type Outcome struct {
+	ID         string
+	Status     Status
+	Findings   []reporter.CheckResult
+	Evaluated  int
+	Err        error
+	SkipReason string
+	Duration   time.Duration
+	// Passed holds the check's pass records. It is only kept when
+	// Options.IncludePassed is set.
+	Passed []reporter.Evidence
+	// Partial says what a check that passed or failed could not evaluate.
+	Partial string
+}
+
+// Options controls which checks Run executes.
+type Options struct {
+	// Enabled reports whether a check should run. Nil enables every check.
+	Enabled func(id string) bool
+	// IncludePassed keeps the pass records of every check as evidence.
+	IncludePassed bool
+}
+
+// Run executes checks against target one after another, in order. A failing
+// check does not stop the others; checks that have not started when ctx is
+// cancelled are recorded as errored.
+func Run[T any](ctx context.Context, target T, checks []Check[T], opts Options) Outcomes {
+	outcomes := make(Outcomes, 0, len(checks))
+	for _, c := range checks {
+		outcome := Outcome{ID: c.ID()}
+		switch {
+		case opts.Enabled != nil && !opts.Enabled(c.ID()):
+			outcome.Status = StatusSkipped
+			outcome.SkipReason = "disabled in config"
+		case ctx.Err() != nil:
+			outcome.Status = StatusErrored
+			outcome.Err = ctx.Err()
+		default:
+			rec := &recorder{id: c.ID()}
+			start := time.Now()
+			findings, err := c.Run(context.WithValue(ctx, recorderKey{}, rec), target)
+			outcome.Duration = time.Since(start)
+			outcome.Findings = findings
+			if reason, ok := skipReason(err); ok {
+				outcome.Status = StatusSkipped
+				outcome.SkipReason = reason
+			} else if err != nil {
+				outcome.Status = StatusErrored
+				outcome.Err = err
+			} else if len(findings) > 0 {
+				outcome.Status = StatusFailed
+			} else {
+				outcome.Status = StatusPassed
+			}
+			if outcome.Status == StatusPassed || outcome.Status == StatusFailed {
+				outcome.Evaluated = evaluated(findings, rec.passed)
+				outcome.Partial = strings.Join(rec.partial, "; ")
+				if opts.IncludePassed {
+					outcome.Passed = rec.passed
+				}
+			}
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// Outcomes holds the outcome of every check in a run, in order.
+type Outcomes []Outcome
+
+// Findings returns the findings of every check, including partial findings
+// of checks that errored.
+func (o Outcomes) Findings() []reporter.CheckResult {
+	var all []reporter.CheckResult
+	for _, outcome := range o {
+		all = append(all, outcome.Findings...)
+	}
+	return all
+}
+
+// Err returns an error naming every check that errored, or nil.
+func (o Outcomes) Err() error {
+	var errs []string
+	for _, outcome := range o {
+		if outcome.Status == StatusErrored {
+			errs = append(errs, fmt.Sprintf("%s: %v", outcome.ID, outcome.Err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("some checks failed: %v", errs)
+	}
+	return nil
+}
+
+// Runs converts the outcomes into the check runs carried by reports.
+func (o Outcomes) Runs() []reporter.CheckRun {
+	runs := make([]reporter.CheckRun, 0, len(o))
+	for _, outcome := range o {
+		run := reporter.CheckRun{
+			Check:      outcome.ID,
+			Status:     string(outcome.Status),
+			Findings:   len(outcome.Findings),
+			Evaluated:  outcome.Evaluated,
+			Passed:     outcome.Passed,
+			SkipReason: outcome.SkipReason,
+			Partial:    outcome.Partial,
+			Duration:   outcome.Duration,
+		}
+		if outcome.Err != nil {
+			run.Error = outcome.Err.Error()
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
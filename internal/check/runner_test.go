@@ -0,0 +1,140 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+func finding(id string) []reporter.CheckResult {
+	return []reporter.CheckResult{{CheckName: id, Severity: "LOW", ResourceID: "r"}}
+}
+
+func TestRunStatuses(t *testing.T) {
+	checks := []Check[string]{
+		New("git-repo-size", func(ctx context.Context, target string) ([]reporter.CheckResult, error) {
+			return nil, nil
+		}),
+		New("git-large-file", func(ctx context.Context, target string) ([]reporter.CheckResult, error) {
+			return finding("git-large-file"), nil
+		}),
+		New("git-stale-branch", func(ctx context.Context, target string) ([]reporter.CheckResult, error) {
+			return nil, Skip("shallow clone")
+		}),
+		New("terraform-fmt", func(ctx context.Context, target string) ([]reporter.CheckResult, error) {
+			return nil, errors.New("boom")
+		}),
+		New("terraform-validate", func(ctx context.Context, target string) ([]reporter.CheckResult, error) {
+			t.Error("disabled check should not run")
+			return nil, nil
+		}),
+	}
+
+	outcomes := Run(context.Background(), "repo", checks, Options{
+		Enabled: func(id string) bool { return id != "terraform-validate" },
+	})
+
+	want := []Status{StatusPassed, StatusFailed, StatusSkipped, StatusErrored, StatusSkipped}
+	if len(outcomes) != len(want) {
+		t.Fatalf("got %d outcomes, want %d", len(outcomes), len(want))
+	}
+	for i, o := range outcomes {
+		if o.Status != want[i] {
+			t.Errorf("%s: status = %s, want %s", o.ID, o.Status, want[i])
+		}
+	}
+	if outcomes[2].SkipReason != "shallow clone" || outcomes[4].SkipReason != "disabled in config" {
+		t.Errorf("skip reasons = %q, %q", outcomes[2].SkipReason, outcomes[4].SkipReason)
+	}
+	if len(outcomes.Findings()) != 1 {
+		t.Errorf("Findings() = %d, want 1", len(outcomes.Findings()))
+	}
+	err := outcomes.Err()
+	if err == nil || !strings.Contains(err.Error(), "terraform-fmt: boom") {
+		t.Errorf("Err() = %v, want it to name terraform-fmt", err)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ran := false
+	outcomes := Run(ctx, 0, []Check[int]{
+		New("git-repo-size", func(ctx context.Context, target int) ([]reporter.CheckResult, error) {
+			ran = true
+			return nil, nil
+		}),
+	}, Options{})
+
+	if ran {
+		t.Error("check should not start after cancellation")
+	}
+	if outcomes[0].Status != StatusErrored || !errors.Is(outcomes[0].Err, context.Canceled) {
+		t.Errorf("outcome = %+v, want errored with context.Canceled", outcomes[0])
+	}
+}
+
+func TestNewUnknownIDPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a check missing from the catalog")
+		}
+	}()
+	New("no-such-check", func(ctx context.Context, target int) ([]reporter.CheckResult, error) {
+		return nil, nil
+	})
+}
+
+func TestOutcomesRuns(t *testing.T) {
+	outcomes := Outcomes{
+		{ID: "git-large-file", Status: StatusFailed, Findings: finding("git-large-file")},
+		{ID: "git-stale-branch", Status: StatusSkipped, SkipReason: "shallow clone"},
+		{ID: "terraform-fmt", Status: StatusErrored, Err: errors.New("boom")},
+	}
+
+	runs := outcomes.Runs()
+	if len(runs) != 3 {
+		t.Fatalf("got %d runs, want 3", len(runs))
+	}
+	if runs[0].Status != reporter.CheckFailed || runs[0].Findings != 1 {
+		t.Errorf("runs[0] = %+v", runs[0])
+	}
+	if runs[1].Status != reporter.CheckSkipped || runs[1].SkipReason != "shallow clone" {
+		t.Errorf("runs[1] = %+v", runs[1])
+	}
+	if runs[2].Status != reporter.CheckErrored || runs[2].Error != "boom" {
+		t.Errorf("runs[2] = %+v", runs[2])
+	}
+}
+
+func TestRunPassRecords(t *testing.T) {
+	checks := []Check[string]{
+		New("git-stale-branch", func(ctx context.Context, target string) ([]reporter.CheckResult, error) {
+			Pass(ctx, "git-stale-branch", "main", "updated today")
+			Pass(ctx, "git-large-file", "main", "recorded for another check")
+			Partial(ctx, "1 of 3 branches unreadable")
+			return []reporter.CheckResult{{CheckName: "git-stale-branch", ResourceID: "old"}}, nil
+		}),
+	}
+
+	for _, include := range []bool{false, true} {
+		outcomes := Run(context.Background(), "repo", checks, Options{IncludePassed: include})
+		o := outcomes[0]
+		if o.Evaluated != 2 {
+			t.Errorf("include %v: Evaluated = %d, want 2", include, o.Evaluated)
+		}
+		if want := map[bool]int{false: 0, true: 1}[include]; len(o.Passed) != want {
+			t.Errorf("include %v: Passed = %+v, want %d records", include, o.Passed, want)
+		}
+		if o.Partial != "1 of 3 branches unreadable" {
+			t.Errorf("include %v: Partial = %q", include, o.Partial)
+		}
+		if run := outcomes.Runs()[0]; run.Evaluated != 2 || len(run.Passed) != len(o.Passed) || run.Partial != o.Partial {
+			t.Errorf("include %v: run = %+v", include, run)
+		}
+	}
+}
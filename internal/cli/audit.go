@@ -6,6 +6,8 @@ import (
 	"os"
 
 	awspkg "github.com/kaustuvbot/devopsctl/internal/aws"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/config"
 	dockerpkg "github.com/kaustuvbot/devopsctl/internal/docker"
 	gitpkg "github.com/kaustuvbot/devopsctl/internal/git"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
@@ -18,26 +20,45 @@ var auditCmd = &cobra.Command{
 	Long:  `Run infrastructure audit checks across different platforms.`,
 }
 
+var awsRegions []string
+
 var auditAWSCmd = &cobra.Command{
 	Use:   "aws",
 	Short: "Audit AWS infrastructure",
 	Long:  `Audit AWS IAM, S3, EC2 security groups, and EBS volumes.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		clients, err := awspkg.NewAWSClients(AppConfig.AWS)
+		pipeline, err := newResultPipeline()
 		if err != nil {
-			return fmt.Errorf("failed to initialize AWS clients: %w", err)
+			return err
+		}
+		fw, err := selectedFramework()
+		if err != nil {
+			return err
 		}
 
-		results, err := awspkg.RunAll(context.Background(), clients, AppConfig.AWS)
+		clients, err := awspkg.NewAWSClients(AppConfig.AWS)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: some checks encountered errors: %v\n", err)
+			return checkError(fmt.Errorf("failed to initialize AWS clients: %w", err))
 		}
 
-		// Apply filters: ignore patterns and quiet mode
-		results = filterByIgnore(results, AppConfig.Ignore.Checks)
-		results = filterBySeverity(results, quiet)
+		ctx, cancel := commandContext()
+		defer cancel()
 
-		report := &reporter.Report{Module: "aws", Results: results}
+		outcomes, accounts, checksErr := runAWS(ctx, clients, AppConfig.AWS)
+		results := outcomes.Findings()
+		if checksErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: some checks encountered errors: %v\n", checksErr)
+		}
+
+		// Apply severity overrides, baseline, suppressions and quiet mode
+		report := pipeline.apply("aws", results, outcomes.Runs())
+		report.Accounts = reporter.CountAccounts(accounts, report.Results)
+		// Controls are evaluated before the baseline and --quiet hide
+		// findings.
+		if fw != nil {
+			report.Compliance = fw.Evaluate(report.Checks, pipeline.controlResults("aws", results))
+		}
+		report.Results = filterBySeverity(report.Results, quiet)
 
 		w, err := resolveWriter(cmd)
 		if err != nil {
@@ -48,17 +69,27 @@ var auditAWSCmd = &cobra.Command{
 		}
 
 		rep := resolveReporter()
-		if err := rep.Render(w, report); err != nil {
-			return err
+		if err := rep.Render(w, &report); err != nil {
+			return checkError(fmt.Errorf("cannot write report: %w", err))
 		}
 
-		if code := exitCodeForResults(results); code > 0 {
-			os.Exit(code)
-		}
-		return nil
+		return runOutcome(report.Results, checksErr != nil)
 	},
 }
 
+// runAWS runs the AWS checks against the account of the credentials or, when
+// aws.accounts is set, against each listed account. It returns the outcome of
+// each check across accounts, the summary of each account and an error
+// naming the checks and accounts that failed.
+func runAWS(ctx context.Context, clients *awspkg.AWSClients, cfg config.AWSConfig) (check.Outcomes, []reporter.AccountSummary, error) {
+	if len(cfg.Accounts) == 0 {
+		outcomes := awspkg.RunAll(ctx, clients, cfg, checkOptions())
+		return outcomes, nil, outcomes.Err()
+	}
+	runs := awspkg.RunAccounts(ctx, clients, cfg, checkOptions())
+	return runs.Outcomes(), runs.Summaries(), runs.Err()
+}
+
 var dockerfilePath string
 var dockerImage string
 
@@ -67,22 +98,32 @@ var auditDockerCmd = &cobra.Command{
 	Short: "Audit Docker configuration",
 	Long:  `Run static checks against a Dockerfile and optionally scan an image with Trivy.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		pipeline, err := newResultPipeline()
+		if err != nil {
+			return err
+		}
+
 		dockerCfg := AppConfig.Docker
 		if dockerfilePath != "" {
 			dockerCfg.DockerfilePath = dockerfilePath
 		}
 
-		opts := dockerpkg.RunOptions{ImageName: dockerImage}
-		results, err := dockerpkg.RunAll(dockerCfg, opts)
+		ctx, cancel := commandContext()
+		defer cancel()
+
+		opts := dockerpkg.RunOptions{ImageName: dockerImage, Checks: checkOptions()}
+		outcomes, err := dockerpkg.RunAll(ctx, dockerCfg, opts)
 		if err != nil {
-			return err
+			return checkError(err)
+		}
+		results, checksErr := outcomes.Findings(), outcomes.Err()
+		if checksErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: some checks encountered errors: %v\n", checksErr)
 		}
 
-		// Apply filters: ignore patterns and quiet mode
-		results = filterByIgnore(results, AppConfig.Ignore.Checks)
-		results = filterBySeverity(results, quiet)
-
-		report := &reporter.Report{Module: "docker", Results: results}
+		// Apply severity overrides, baseline, suppressions and quiet mode
+		report := pipeline.apply("docker", results, outcomes.Runs())
+		report.Results = filterBySeverity(report.Results, quiet)
 
 		w, err := resolveWriter(cmd)
 		if err != nil {
@@ -93,14 +134,11 @@ var auditDockerCmd = &cobra.Command{
 		}
 
 		rep := resolveReporter()
-		if err := rep.Render(w, report); err != nil {
-			return err
+		if err := rep.Render(w, &report); err != nil {
+			return checkError(fmt.Errorf("cannot write report: %w", err))
 		}
 
-		if code := exitCodeForResults(results); code > 0 {
-			os.Exit(code)
-		}
-		return nil
+		return runOutcome(report.Results, checksErr != nil)
 	},
 }
 
@@ -111,26 +149,33 @@ var auditGitCmd = &cobra.Command{
 	Short: "Audit Git repository",
 	Long:  `Audit Git repository for hygiene issues: size, stale branches, large files.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		pipeline, err := newResultPipeline()
+		if err != nil {
+			return err
+		}
+
 		repoPath := gitRepoPath
 		if repoPath == "" {
 			cwd, err := os.Getwd()
 			if err != nil {
-				return fmt.Errorf("failed to get current directory: %w", err)
+				return checkError(fmt.Errorf("failed to get current directory: %w", err))
 			}
 			repoPath = cwd
 		}
 
+		ctx, cancel := commandContext()
+		defer cancel()
+
 		runner := gitpkg.NewRunner(repoPath, AppConfig.Git)
-		results, err := runner.RunAll(context.Background())
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: some checks encountered errors: %v\n", err)
+		outcomes := runner.RunAll(ctx, checkOptions())
+		results, checksErr := outcomes.Findings(), outcomes.Err()
+		if checksErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: some checks encountered errors: %v\n", checksErr)
 		}
 
-		// Apply filters: ignore patterns and quiet mode
-		results = filterByIgnore(results, AppConfig.Ignore.Checks)
-		results = filterBySeverity(results, quiet)
-
-		report := &reporter.Report{Module: "git", Results: results}
+		// Apply severity overrides, baseline, suppressions and quiet mode
+		report := pipeline.apply("git", results, outcomes.Runs())
+		report.Results = filterBySeverity(report.Results, quiet)
 
 		w, err := resolveWriter(cmd)
 		if err != nil {
@@ -141,21 +186,21 @@ var auditGitCmd = &cobra.Command{
 		}
 
 		rep := resolveReporter()
-		if err := rep.Render(w, report); err != nil {
-			return err
+		if err := rep.Render(w, &report); err != nil {
+			return checkError(fmt.Errorf("cannot write report: %w", err))
 		}
 
-		if code := exitCodeForResults(results); code > 0 {
-			os.Exit(code)
-		}
-		return nil
+		return runOutcome(report.Results, checksErr != nil)
 	},
 }
 
 func init() {
+	auditAWSCmd.Flags().StringVar(&frameworkID, "framework", "", "group results by the controls of a compliance framework, e.g. cis-aws-1.5")
+	auditAWSCmd.Flags().StringSliceVar(&awsRegions, "region", nil, "AWS region to scan with regional checks; repeat for several, or all (overrides config)")
 	auditDockerCmd.Flags().StringVar(&dockerfilePath, "file", "", "path to Dockerfile (overrides config)")
 	auditDockerCmd.Flags().StringVar(&dockerImage, "image", "", "container image to scan with Trivy")
 	auditGitCmd.Flags().StringVar(&gitRepoPath, "repo", "", "path to Git repository (defaults to current directory)")
+	auditCmd.PersistentFlags().StringVar(&baselineFile, "baseline", "", "report only findings not in this baseline file")
 	auditCmd.AddCommand(auditAWSCmd)
 	auditCmd.AddCommand(auditDockerCmd)
 	auditCmd.AddCommand(auditGitCmd)
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kaustuvbot/devopsctl/internal/baseline"
+	"github.com/kaustuvbot/devopsctl/internal/doctor"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+	"github.com/spf13/cobra"
+)
+
+var baselineFile string
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage baseline files of known findings",
+	Long: `A baseline records the findings that exist today, so that audit, validate
+and doctor can report only new findings with --baseline.`,
+}
+
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create [file]",
+	Short: "Snapshot current findings into a baseline file",
+	Long: `Run every module like doctor and write the current findings to a baseline
+file (default ` + baseline.DefaultPath + `). Findings are identified by module,
+check and resource, after severity overrides and suppressions are applied.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := baseline.DefaultPath
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		pol := loadPolicy()
+		reports, err := runDoctorModules()
+		if err != nil {
+			return checkError(err)
+		}
+
+		var modules []reporter.Report
+		for _, r := range reports {
+			switch {
+			case r.SkipReason != "":
+				fmt.Fprintf(os.Stderr, "warning: %s module skipped (%s), its findings are not in the baseline\n", r.Module, r.SkipReason)
+				continue
+			case r.Status != doctor.StatusOK:
+				fmt.Fprintf(os.Stderr, "warning: %s module did not complete, its baseline may be partial\n", r.Module)
+			}
+			results := pol.Apply(r.Module, r.Results)
+			results, _ = pol.Suppress(r.Module, results)
+			modules = append(modules, reporter.Report{Module: r.Module, Results: results})
+		}
+
+		b := baseline.New(modules, Version)
+		if err := b.Save(path); err != nil {
+			return checkError(err)
+		}
+		fmt.Printf("Baseline with %d findings written to %s\n", len(b.Findings), path)
+		return nil
+	},
+}
+
+func init() {
+	defaults := doctor.DefaultOptions()
+	baselineCreateCmd.Flags().IntVar(&doctorConcurrency, "concurrency", defaults.Concurrency, "maximum number of modules to run in parallel")
+	baselineCreateCmd.Flags().DurationVar(&doctorModuleTimeout, "module-timeout", defaults.ModuleTimeout, "time limit for each module (0 means no limit)")
+	baselineCreateCmd.Flags().StringSliceVar(&awsRegions, "region", nil, "AWS region to scan with regional checks; repeat for several, or all (overrides config)")
+	baselineCmd.AddCommand(baselineCreateCmd)
+	rootCmd.AddCommand(baselineCmd)
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kaustuvbot/devopsctl/internal/catalog"
+	"github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/framework"
+	"github.com/kaustuvbot/devopsctl/internal/severity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checksModule   string
+	checksSeverity []string
+)
+
+var checksCmd = &cobra.Command{
+	Use:   "checks",
+	Short: "List and explain the available checks",
+}
+
+var checksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available checks",
+	Long: `List every check with its module and default severity. Use --format json
+for machine-readable output.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if checksModule != "" && !config.IsModule(checksModule) {
+			return usageError(fmt.Errorf("unknown module %q: must be one of %s", checksModule, strings.Join(config.Modules, ", ")))
+		}
+		levels := map[severity.Level]bool{}
+		for _, s := range checksSeverity {
+			l := severity.Level(strings.ToUpper(s))
+			if !severity.IsValid(string(l)) {
+				return usageError(fmt.Errorf("invalid --severity %q: must be one of LOW, MEDIUM, HIGH, CRITICAL", s))
+			}
+			levels[l] = true
+		}
+
+		checks := []catalog.Check{}
+		for _, c := range catalog.All() {
+			if checksModule != "" && c.Module != checksModule {
+				continue
+			}
+			if len(levels) > 0 && !levels[c.Severity] {
+				continue
+			}
+			checks = append(checks, c)
+		}
+
+		return writeListing(cmd, func(w io.Writer, asJSON bool) error {
+			if asJSON {
+				return encodeJSON(w, checks)
+			}
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "ID\tMODULE\tSEVERITY\tTITLE")
+			for _, c := range checks {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.ID, c.Module, c.Severity, c.Title)
+			}
+			return tw.Flush()
+		})
+	},
+}
+
+var checksExplainCmd = &cobra.Command{
+	Use:   "explain <check-id>",
+	Short: "Show the documentation of a check",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, ok := catalog.Lookup(args[0])
+		if !ok {
+			return usageError(fmt.Errorf("unknown check %q (see 'devopsctl checks list')", args[0]))
+		}
+		frameworks, err := loadFrameworks()
+		if err != nil {
+			return err
+		}
+		controls := frameworks.ControlsFor(c.ID)
+
+		return writeListing(cmd, func(w io.Writer, asJSON bool) error {
+			if asJSON {
+				return encodeJSON(w, struct {
+					catalog.Check
+					Controls []framework.Ref `json:"controls,omitempty"`
+				}{c, controls})
+			}
+			return explainCheck(w, c, controls)
+		})
+	},
+}
+
+// writeListing writes catalog or config output to --output or stdout. Only
+// the table and JSON formats apply to them.
+func writeListing(cmd *cobra.Command, render func(w io.Writer, asJSON bool) error) error {
+	asJSON := outputFormat == "json" || jsonOutput
+	if !asJSON && outputFormat != "table" {
+		return usageError(fmt.Errorf("%s does not support --format %s (use table or json)", cmd.CommandPath(), outputFormat))
+	}
+
+	w, err := resolveWriter(cmd)
+	if err != nil {
+		return err
+	}
+	if w != os.Stdout {
+		defer w.Close()
+	}
+
+	if err := render(w, asJSON); err != nil {
+		return checkError(fmt.Errorf("cannot write output: %w", err))
+	}
+	return nil
+}
+
+func explainCheck(w io.Writer, c catalog.Check, controls []framework.Ref) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s\n\n", c.ID, c.Title)
+	fmt.Fprintf(&b, "Module:    %s\n", c.Module)
+	fmt.Fprintf(&b, "Severity:  %s\n\n", c.Severity)
+	fmt.Fprintf(&b, "%s\n\n", c.Description)
+	fmt.Fprintf(&b, "Why it matters:\n  %s\n\n", c.Rationale)
+	b.WriteString("Remediation:\n")
+	for i, step := range c.Remediation {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, step)
+	}
+	if len(c.References) > 0 {
+		b.WriteString("\nReferences:\n")
+		for _, ref := range c.References {
+			fmt.Fprintf(&b, "  - %s\n", ref)
+		}
+	}
+	if len(controls) > 0 {
+		b.WriteString("\nControls:\n")
+		for _, ref := range controls {
+			fmt.Fprintf(&b, "  - %s %s\n", ref.Framework, ref.Control)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func encodeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func init() {
+	checksListCmd.Flags().StringVar(&checksModule, "module", "", "only list checks of this module: aws, docker, terraform or git")
+	checksListCmd.Flags().StringSliceVar(&checksSeverity, "severity", nil, "only list checks with these default severities, e.g. high,critical")
+	checksCmd.AddCommand(checksListCmd)
+	checksCmd.AddCommand(checksExplainCmd)
+	rootCmd.AddCommand(checksCmd)
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/framework"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	configShowOrigin bool
+	configInitForce  bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Create, validate and inspect configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration",
+	Long: `Print the configuration after merging every layer, lowest precedence
+first: defaults, $XDG_CONFIG_HOME/devopsctl/config.yaml, the nearest
+.devopsctl.yaml up to the Git root (or --config), DEVOPSCTL_* environment
+variables and flags. Use --origin to list each key with the layer that set it;
+--format json always includes the origin.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := AppConfig.Settings(configOrigins)
+		if err != nil {
+			return checkError(err)
+		}
+
+		return writeListing(cmd, func(w io.Writer, asJSON bool) error {
+			switch {
+			case asJSON:
+				// Config has no JSON field names; list keys as in YAML.
+				return encodeJSON(w, settings)
+			case configShowOrigin:
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "KEY\tVALUE\tORIGIN")
+				for _, s := range settings {
+					fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Key, s.Value, s.Origin)
+				}
+				return tw.Flush()
+			}
+			encoder := yaml.NewEncoder(w)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(AppConfig); err != nil {
+				return err
+			}
+			return encoder.Close()
+		})
+	},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init [file]",
+	Short: "Write a commented config file with every default",
+	Long: `Write a config file (default .devopsctl.yaml) that documents every key with
+its default value, commented out. Uncomment a key to change it.`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{skipConfigAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := ".devopsctl.yaml"
+		if len(args) == 1 {
+			path = args[0]
+		}
+		if _, err := os.Stat(path); err == nil && !configInitForce {
+			return usageError(fmt.Errorf("%s already exists (use --force to overwrite)", path))
+		}
+		if err := os.WriteFile(path, config.Template, 0644); err != nil {
+			return checkError(fmt.Errorf("cannot write config: %w", err))
+		}
+		fmt.Printf("Config written to %s\n", path)
+		return nil
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file...]",
+	Short: "Check config files for unknown keys and invalid values",
+	Long: `Validate the given config files, or without arguments every config layer
+that applies in the current directory. Errors name the file and line. The
+command exits with code 6 if any file is invalid, so it can gate CI.`,
+	Annotations: map[string]string{skipConfigAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			if cfgFile != "" {
+				if _, err := os.Stat(cfgFile); err != nil {
+					return usageError(err)
+				}
+			}
+			if err := initConfig(cmd); err != nil {
+				return usageError(err)
+			}
+			if _, err := loadFrameworks(); err != nil {
+				return err
+			}
+			files := configFiles()
+			if len(files) == 0 {
+				fmt.Println("No config file found; defaults apply")
+			}
+			for _, path := range files {
+				fmt.Printf("%s: ok\n", path)
+			}
+			return nil
+		}
+
+		invalid := 0
+		for _, path := range args {
+			err := validateConfigFile(path)
+			if err != nil {
+				invalid++
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+			fmt.Printf("%s: ok\n", path)
+		}
+		if invalid > 0 {
+			return &exitError{code: ExitUsage}
+		}
+		return nil
+	},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the config file",
+	Long: `Print the JSON Schema of the config file, for editors and CI linters that
+validate YAML against a schema.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{skipConfigAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w, err := resolveWriter(cmd)
+		if err != nil {
+			return err
+		}
+		if w != os.Stdout {
+			defer w.Close()
+		}
+		if _, err := w.Write(config.Schema); err != nil {
+			return checkError(fmt.Errorf("cannot write output: %w", err))
+		}
+		return nil
+	},
+}
+
+// validateConfigFile loads a single config file on top of the defaults,
+// including the framework files it names.
+func validateConfigFile(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	if _, err := framework.Load(cfg.FrameworkFiles...); err != nil {
+		return fmt.Errorf("%s: framework_files: %w", path, err)
+	}
+	return nil
+}
+
+func init() {
+	configShowCmd.Flags().BoolVar(&configShowOrigin, "origin", false, "list each key with the file, environment variable or flag that set it")
+	configInitCmd.Flags().BoolVar(&configInitForce, "force", false, "overwrite an existing file")
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -2,53 +2,126 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
+	awspkg "github.com/kaustuvbot/devopsctl/internal/aws"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/config"
+	dockerpkg "github.com/kaustuvbot/devopsctl/internal/docker"
 	"github.com/kaustuvbot/devopsctl/internal/doctor"
+	gitpkg "github.com/kaustuvbot/devopsctl/internal/git"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
+	terraformpkg "github.com/kaustuvbot/devopsctl/internal/terraform"
 	"github.com/spf13/cobra"
 )
 
 // awsModule wraps AWS checks as a doctor.Module
-type awsModule struct{}
+type awsModule struct {
+	cfg      config.AWSConfig
+	outcomes check.Outcomes
+	accounts []reporter.AccountSummary
+}
 
 func (m *awsModule) Name() string { return "aws" }
 
+func (m *awsModule) CheckRuns() []reporter.CheckRun { return m.outcomes.Runs() }
+
+func (m *awsModule) AccountSummaries() []reporter.AccountSummary { return m.accounts }
+
 func (m *awsModule) Run(ctx context.Context) ([]reporter.CheckResult, error) {
-	// Will be implemented in CLI after AWS clients are available
-	return []reporter.CheckResult{}, nil
+	if !m.cfg.Enabled {
+		return nil, doctor.Skip("disabled in config")
+	}
+	clients, err := awspkg.NewAWSClients(m.cfg)
+	if err != nil {
+		return nil, doctor.Skip(err.Error())
+	}
+	if err := clients.CheckCredentials(ctx); err != nil {
+		return nil, doctor.Skip(err.Error())
+	}
+	outcomes, accounts, err := runAWS(ctx, clients, m.cfg)
+	m.outcomes, m.accounts = outcomes, accounts
+	return outcomes.Findings(), err
 }
 
 // dockerModule wraps Docker checks as a doctor.Module
-type dockerModule struct{}
+type dockerModule struct {
+	cfg      config.DockerConfig
+	outcomes check.Outcomes
+}
 
 func (m *dockerModule) Name() string { return "docker" }
 
+func (m *dockerModule) CheckRuns() []reporter.CheckRun { return m.outcomes.Runs() }
+
 func (m *dockerModule) Run(ctx context.Context) ([]reporter.CheckResult, error) {
-	// Will be implemented in CLI after Docker runner is available
-	return []reporter.CheckResult{}, nil
+	if !m.cfg.Enabled {
+		return nil, doctor.Skip("disabled in config")
+	}
+	if _, err := os.Stat(m.cfg.DockerfilePath); err != nil {
+		return nil, doctor.Skip(fmt.Sprintf("no Dockerfile at %q", m.cfg.DockerfilePath))
+	}
+	outcomes, err := dockerpkg.RunAll(ctx, m.cfg, dockerpkg.RunOptions{Checks: checkOptions()})
+	if err != nil {
+		return nil, err
+	}
+	m.outcomes = outcomes
+	return outcomes.Findings(), outcomes.Err()
 }
 
 // terraformModule wraps Terraform checks as a doctor.Module
-type terraformModule struct{}
+type terraformModule struct {
+	cfg      config.TerraformConfig
+	outcomes check.Outcomes
+}
 
 func (m *terraformModule) Name() string { return "terraform" }
 
+func (m *terraformModule) CheckRuns() []reporter.CheckRun { return m.outcomes.Runs() }
+
 func (m *terraformModule) Run(ctx context.Context) ([]reporter.CheckResult, error) {
-	// Will be implemented in CLI after Terraform runner is available
-	return []reporter.CheckResult{}, nil
+	if !m.cfg.Enabled {
+		return nil, doctor.Skip("disabled in config")
+	}
+	dir := m.cfg.TfDir
+	if dir == "" {
+		dir = "."
+	}
+	runner := terraformpkg.NewRunner(dir)
+	if !runner.HasTerraformFiles() {
+		return nil, doctor.Skip(fmt.Sprintf("no Terraform files in %q", dir))
+	}
+	m.outcomes = runner.RunAll(ctx, checkOptions())
+	return m.outcomes.Findings(), m.outcomes.Err()
 }
 
 // gitModule wraps Git checks as a doctor.Module
-type gitModule struct{}
+type gitModule struct {
+	cfg      config.GitConfig
+	outcomes check.Outcomes
+}
 
 func (m *gitModule) Name() string { return "git" }
 
+func (m *gitModule) CheckRuns() []reporter.CheckRun { return m.outcomes.Runs() }
+
 func (m *gitModule) Run(ctx context.Context) ([]reporter.CheckResult, error) {
-	// Will be implemented in CLI after Git runner is available
-	return []reporter.CheckResult{}, nil
+	if !m.cfg.Enabled {
+		return nil, doctor.Skip("disabled in config")
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	runner := gitpkg.NewRunner(cwd, m.cfg)
+	if !runner.IsRepo() {
+		return nil, doctor.Skip(fmt.Sprintf("%q is not a git repository", cwd))
+	}
+	m.outcomes = runner.RunAll(ctx, checkOptions())
+	return m.outcomes.Findings(), m.outcomes.Err()
 }
 
 var doctorCmd = &cobra.Command{
@@ -57,30 +130,45 @@ var doctorCmd = &cobra.Command{
 	Long: `Run all available audit and validation checks, aggregate results,
 and generate a comprehensive health report.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		engine := doctor.NewEngine()
-
-		// Register all modules
-		if err := engine.Register(&awsModule{}); err != nil {
-			return fmt.Errorf("failed to register aws module: %w", err)
-		}
-		if err := engine.Register(&dockerModule{}); err != nil {
-			return fmt.Errorf("failed to register docker module: %w", err)
-		}
-		if err := engine.Register(&terraformModule{}); err != nil {
-			return fmt.Errorf("failed to register terraform module: %w", err)
+		pipeline, err := newResultPipeline()
+		if err != nil {
+			return err
 		}
-		if err := engine.Register(&gitModule{}); err != nil {
-			return fmt.Errorf("failed to register git module: %w", err)
+		fw, err := selectedFramework()
+		if err != nil {
+			return err
 		}
 
-		// Run all modules
-		reports, err := engine.RunAll(context.Background())
+		reports, err := runDoctorModules()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: some modules encountered errors: %v\n", err)
+			return checkError(err)
 		}
 
-		// Compute summary
-		summary := doctor.ComputeSummary(reports)
+		// Severity overrides, the baseline and suppressions are applied
+		// before scoring and the exit code. Controls are evaluated on the
+		// findings before the baseline.
+		var controlResults []reporter.CheckResult
+		for i := range reports {
+			controlResults = append(controlResults, pipeline.controlResults(reports[i].Module, reports[i].Results)...)
+			report := pipeline.apply(reports[i].Module, reports[i].Results, reports[i].Checks)
+			reports[i].Results = report.Results
+			reports[i].Suppressed = report.Suppressed
+			reports[i].Resolved = report.Resolved
+		}
+
+		doc := doctor.NewDocument(reports, reporter.Metadata{
+			Tool:    "devopsctl",
+			Version: Version,
+			Command: "doctor",
+			Profile: AppConfig.ActiveProfile,
+		})
+		if fw != nil {
+			var runs []reporter.CheckRun
+			for _, m := range doc.Modules {
+				runs = append(runs, m.Checks...)
+			}
+			doc.Compliance = fw.Evaluate(runs, controlResults)
+		}
 
 		// Output results
 		w, err := resolveWriter(cmd)
@@ -92,33 +180,74 @@ and generate a comprehensive health report.`,
 		}
 
 		rep := resolveReporter()
-
-		// Render each module's results
-		for _, r := range reports {
-			report := &reporter.Report{Module: r.Module, Results: r.Results}
-			if err := rep.Render(w, report); err != nil {
-				return err
-			}
-			if r.Error != "" {
-				fmt.Fprintf(w, "  [ERROR] %s\n", r.Error)
-			}
-		}
-
-		// Print summary for JSON output
-		if jsonOutput {
-			summaryJSON, _ := json.MarshalIndent(summary, "", "  ")
-			fmt.Fprintf(w, "\n%s\n", summaryJSON)
+		if err := rep.RenderDocument(w, doc); err != nil {
+			return checkError(fmt.Errorf("cannot write report: %w", err))
 		}
 
 		// Exit with appropriate code
-		if code := doctor.ExitCode(reports); code > 0 {
-			os.Exit(code)
+		var results []reporter.CheckResult
+		checksFailed := false
+		for _, r := range reports {
+			results = append(results, r.Results...)
+			if r.Status == doctor.StatusFailed || r.Status == doctor.StatusTimedOut {
+				checksFailed = true
+			}
 		}
-
-		return nil
+		return runOutcome(results, checksFailed)
 	},
 }
 
+// runDoctorModules runs every module through the doctor engine with the
+// scheduling flags and returns their reports, including partial results
+// after an interrupt.
+func runDoctorModules() ([]doctor.ModuleReport, error) {
+	engine := doctor.NewEngineWithOptions(doctor.Options{
+		Concurrency:   doctorConcurrency,
+		ModuleTimeout: doctorModuleTimeout,
+	})
+
+	// Register all modules
+	if err := engine.Register(&awsModule{cfg: AppConfig.AWS}); err != nil {
+		return nil, fmt.Errorf("failed to register aws module: %w", err)
+	}
+	if err := engine.Register(&dockerModule{cfg: AppConfig.Docker}); err != nil {
+		return nil, fmt.Errorf("failed to register docker module: %w", err)
+	}
+	if err := engine.Register(&terraformModule{cfg: AppConfig.Terraform}); err != nil {
+		return nil, fmt.Errorf("failed to register terraform module: %w", err)
+	}
+	if err := engine.Register(&gitModule{cfg: AppConfig.Git}); err != nil {
+		return nil, fmt.Errorf("failed to register git module: %w", err)
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	// Run all modules
+	reports, err := engine.RunAll(ctx)
+	if reports == nil && err != nil {
+		return nil, fmt.Errorf("cannot plan doctor run: %w", err)
+	}
+	if errors.Is(ctx.Err(), context.Canceled) {
+		fmt.Fprintln(os.Stderr, "interrupted: reporting partial results")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: some modules encountered errors: %v\n", err)
+	}
+	return reports, nil
+}
+
+var (
+	doctorConcurrency   int
+	doctorModuleTimeout time.Duration
+)
+
 func init() {
+	defaults := doctor.DefaultOptions()
+	doctorCmd.Flags().IntVar(&doctorConcurrency, "concurrency", defaults.Concurrency, "maximum number of modules to run in parallel")
+	doctorCmd.Flags().DurationVar(&doctorModuleTimeout, "module-timeout", defaults.ModuleTimeout, "time limit for each module (0 means no limit)")
+	doctorCmd.Flags().StringVar(&baselineFile, "baseline", "", "report only findings not in this baseline file")
+	doctorCmd.Flags().StringVar(&frameworkID, "framework", "", "group results by the controls of a compliance framework, e.g. cis-aws-1.5")
+	doctorCmd.Flags().StringSliceVar(&awsRegions, "region", nil, "AWS region to scan with regional checks; repeat for several, or all (overrides config)")
 	rootCmd.AddCommand(doctorCmd)
 }
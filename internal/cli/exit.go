@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+	"github.com/kaustuvbot/devopsctl/internal/severity"
+)
+
+// Exit codes. Findings at or above the --fail-on threshold exit with the
+// code of the highest such severity (1 LOW to 4 CRITICAL), so scripts can
+// still branch on severity. Findings take precedence over check errors
+// because they are real even when other checks could not run.
+const (
+	ExitOK          = 0
+	ExitCheckErrors = 5
+	ExitUsage       = 6
+)
+
+// exitError carries a process exit code back to Execute, so commands never
+// call os.Exit themselves and deferred cleanup still runs. A nil err means
+// the outcome was already reported and nothing more should be printed.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// usageError marks err as a usage or configuration error.
+func usageError(err error) error {
+	return &exitError{code: ExitUsage, err: err}
+}
+
+// checkError marks err as a failure to execute checks.
+func checkError(err error) error {
+	return &exitError{code: ExitCheckErrors, err: err}
+}
+
+// exitCode maps an error returned by a command to a process exit code.
+// Errors that were not classified by the command come from cobra itself,
+// such as unknown commands or invalid arguments, and count as usage errors.
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return ExitUsage
+}
+
+// runOutcome returns the error a command should return after its report has
+// been written: a findings exit code when results meet the --fail-on
+// threshold, ExitCheckErrors when some checks failed to run, or nil.
+func runOutcome(results []reporter.CheckResult, checksFailed bool) error {
+	var levels []severity.Level
+	for _, r := range results {
+		if l := severity.Level(r.Severity); l.Meets(failOnLevel) {
+			levels = append(levels, l)
+		}
+	}
+	if len(levels) > 0 {
+		return &exitError{code: severity.Highest(levels).ExitCode()}
+	}
+	if checksFailed {
+		return &exitError{code: ExitCheckErrors}
+	}
+	return nil
+}
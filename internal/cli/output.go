@@ -4,7 +4,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
+	"github.com/kaustuvbot/devopsctl/internal/baseline"
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/framework"
+	"github.com/kaustuvbot/devopsctl/internal/policy"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 	"github.com/kaustuvbot/devopsctl/internal/severity"
 	"github.com/spf13/cobra"
@@ -16,7 +21,7 @@ func resolveWriter(_ *cobra.Command) (io.WriteCloser, error) {
 	if outputFile != "" {
 		f, err := os.Create(outputFile)
 		if err != nil {
-			return nil, fmt.Errorf("cannot open output file: %w", err)
+			return nil, usageError(fmt.Errorf("cannot open output file: %w", err))
 		}
 		return f, nil
 	}
@@ -32,6 +37,12 @@ func resolveReporter() reporter.Reporter {
 		return reporter.NewJSONReporter(true)
 	case "markdown":
 		return reporter.NewMarkdownReporter()
+	case "sarif":
+		return reporter.NewSARIFReporter(Version)
+	case "html":
+		return reporter.NewHTMLReporter()
+	case "junit":
+		return reporter.NewJUnitReporter(severity.Level(junitFailAt))
 	case "table":
 		return reporter.NewTableReporter()
 	}
@@ -43,38 +54,127 @@ func resolveReporter() reporter.Reporter {
 	return reporter.NewTableReporter()
 }
 
-// exitCodeForResults returns the highest severity exit code from results.
-// Returns 0 if results is empty.
-func exitCodeForResults(results []reporter.CheckResult) int {
-	var levels []severity.Level
-	for _, r := range results {
-		levels = append(levels, severity.Level(r.Severity))
+// checkOptions returns the runner options for the per-check settings in
+// config and --include-passed.
+func checkOptions() check.Options {
+	return check.Options{Enabled: AppConfig.CheckEnabled, IncludePassed: includePassed}
+}
+
+// loadFrameworks returns the built-in compliance frameworks extended by the
+// mapping files in config.
+func loadFrameworks() (*framework.Set, error) {
+	set, err := framework.Load(AppConfig.FrameworkFiles...)
+	if err != nil {
+		return nil, usageError(fmt.Errorf("framework_files: %w", err))
 	}
-	if len(levels) == 0 {
-		return 0
+	return set, nil
+}
+
+// selectedFramework returns the framework named by --framework, or nil when
+// none was selected.
+func selectedFramework() (*framework.Framework, error) {
+	if frameworkID == "" {
+		return nil, nil
 	}
-	return severity.Highest(levels).ExitCode()
+	set, err := loadFrameworks()
+	if err != nil {
+		return nil, err
+	}
+	fw, ok := set.Get(frameworkID)
+	if !ok {
+		return nil, usageError(fmt.Errorf("unknown framework %q: must be one of %s", frameworkID, strings.Join(set.IDs(), ", ")))
+	}
+	return fw, nil
 }
 
-// filterByIgnore filters out checks that match any pattern in Ignore.Checks.
-func filterByIgnore(results []reporter.CheckResult, ignorePatterns []string) []reporter.CheckResult {
-	if len(ignorePatterns) == 0 {
-		return results
+// loadPolicy builds the result policy from config and warns about
+// suppressions that have expired, whose findings are reported again.
+func loadPolicy() *policy.Policy {
+	pol := policy.New(AppConfig)
+	for _, s := range pol.Expired() {
+		fmt.Fprintf(os.Stderr, "warning: suppression of %s expired on %s (owner: %s), findings are reported again\n",
+			s.Check, s.Expires, ownerOrUnknown(s.Owner))
 	}
-	var filtered []reporter.CheckResult
-	for _, r := range results {
-		skip := false
-		for _, pattern := range ignorePatterns {
-			if r.CheckName == pattern {
-				skip = true
-				break
-			}
+	return pol
+}
+
+func ownerOrUnknown(owner string) string {
+	if owner == "" {
+		return "unknown"
+	}
+	return owner
+}
+
+// resultPipeline applies the config-driven steps to each module's results
+// before they are reported, scored and turned into an exit code.
+type resultPipeline struct {
+	policy   *policy.Policy
+	baseline *baseline.Baseline
+}
+
+// newResultPipeline loads the policy from config and, with --baseline, the
+// baseline file. Call it before running checks so a bad baseline path fails
+// fast.
+func newResultPipeline() (*resultPipeline, error) {
+	p := &resultPipeline{policy: loadPolicy()}
+	if baselineFile != "" {
+		b, err := baseline.Load(baselineFile)
+		if err != nil {
+			return nil, usageError(err)
 		}
-		if !skip {
-			filtered = append(filtered, r)
+		p.baseline = b
+	}
+	return p, nil
+}
+
+// apply builds a module report from raw results and the runs of the checks
+// that produced them. Severity overrides come first so every later step sees
+// the final severities. With a baseline, only findings not in it are kept;
+// baseline findings that no longer occur are listed as resolved when their
+// check passed or failed, not when it was skipped or errored. Suppressed
+// findings are attached only with --show-suppressed.
+func (p *resultPipeline) apply(module string, results []reporter.CheckResult, runs []reporter.CheckRun) reporter.Report {
+	report := reporter.Report{Module: module, Profile: AppConfig.ActiveProfile, Checks: runs}
+
+	results = p.policy.Apply(module, results)
+	if p.baseline != nil {
+		var resolved []reporter.CheckResult
+		results, resolved = p.baseline.Compare(module, results)
+		report.Resolved = executedOnly(resolved, runs)
+	}
+
+	results, suppressed := p.policy.Suppress(module, results)
+	report.Results = results
+	if showSuppressed {
+		report.Suppressed = suppressed
+	}
+	return report
+}
+
+// controlResults returns the findings that framework controls are evaluated
+// against: raw results after severity overrides and suppressions but not the
+// baseline, so a control with known failures still fails. Suppressions are
+// the only way to waive a control.
+func (p *resultPipeline) controlResults(module string, results []reporter.CheckResult) []reporter.CheckResult {
+	results, _ = p.policy.Suppress(module, p.policy.Apply(module, results))
+	return results
+}
+
+// executedOnly keeps the results whose check passed or failed in runs. A
+// baseline finding of a check that did not execute is not known to be
+// resolved.
+func executedOnly(results []reporter.CheckResult, runs []reporter.CheckRun) []reporter.CheckResult {
+	executed := make(map[string]bool, len(runs))
+	for _, run := range runs {
+		executed[run.Check] = run.Status == reporter.CheckPassed || run.Status == reporter.CheckFailed
+	}
+	var out []reporter.CheckResult
+	for _, r := range results {
+		if executed[r.CheckName] {
+			out = append(out, r)
 		}
 	}
-	return filtered
+	return out
 }
 
 // filterBySeverity filters results to only include CRITICAL and HIGH severity
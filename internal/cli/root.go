@@ -1,24 +1,55 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/severity"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cfgFile    string
-	jsonOutput bool
-	outputFile string
-	quiet      bool
-	outputFormat string
+	cfgFile        string
+	jsonOutput     bool
+	outputFile     string
+	quiet          bool
+	outputFormat   string
+	timeout        time.Duration
+	junitFailAt    string
+	showSuppressed bool
+	includePassed  bool
+	frameworkID    string
+	profileName    string
+	failOn         string
+
+	// failOnLevel is the resolved --fail-on threshold.
+	failOnLevel = severity.Low
 
 	// AppConfig holds the loaded configuration.
 	AppConfig *config.Config
+	// configOrigins records where each config key of AppConfig came from.
+	configOrigins config.Origins
 )
 
+// skipConfigAnnotation marks commands that start from the default config
+// instead of loading the config layers, because they create or check the
+// config files themselves.
+const skipConfigAnnotation = "devopsctl/skip-config"
+
+// configFlags maps the flags that override a config key to that key. They
+// are the last and highest config layer.
+var configFlags = map[string]string{
+	"fail-on": "fail_on",
+	"region":  "aws.regions",
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "devopsctl",
 	Short: "Infrastructure hygiene and DevOps validation toolkit",
@@ -28,42 +59,132 @@ and DevOps validation across AWS, Docker, Terraform, and Git.
 Run checks against your infrastructure to identify security issues,
 misconfigurations, and maintenance problems.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return initConfig()
+		junitFailAt = strings.ToUpper(junitFailAt)
+		if !severity.IsValid(junitFailAt) {
+			return usageError(fmt.Errorf("invalid --junit-fail-at %q: must be one of LOW, MEDIUM, HIGH, CRITICAL", junitFailAt))
+		}
+		if cmd.Annotations[skipConfigAnnotation] != "" {
+			AppConfig = config.DefaultConfig()
+			configOrigins = config.Origins{}
+		} else if err := initConfig(cmd); err != nil {
+			return usageError(err)
+		}
+		return resolveFailOn()
 	},
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
-// Execute runs the root command.
-func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+// Execute runs the root command and returns the process exit code.
+func Execute() int {
+	err := rootCmd.Execute()
+	var exit *exitError
+	if err != nil && !(errors.As(err, &exit) && exit.err == nil) {
+		fmt.Fprintln(os.Stderr, "Error:", err)
 	}
+	return exitCode(err)
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .devopsctl.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "repo config file (default is the nearest .devopsctl.yaml up to the Git root)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output in JSON format (deprecated, use --format)")
-	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "table", "output format: table, json, markdown")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "table", "output format: table, json, markdown, sarif, junit, html")
 	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "show only CRITICAL and HIGH severity findings")
 	rootCmd.PersistentFlags().StringVar(&outputFile, "output", "", "write report to file")
+	rootCmd.PersistentFlags().BoolVar(&showSuppressed, "show-suppressed", false, "list findings hidden by suppressions and why")
+	rootCmd.PersistentFlags().BoolVar(&includePassed, "include-passed", false, "list every resource that passed each check, as compliance evidence")
+	rootCmd.PersistentFlags().StringVar(&junitFailAt, "junit-fail-at", string(severity.Low), "lowest severity reported as a failure in JUnit output")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "overall time limit for the command, e.g. 10m (0 means no limit)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "config profile to apply, e.g. prod (default $"+config.ProfileEnv+")")
+	rootCmd.PersistentFlags().StringVar(&failOn, "fail-on", "low", "lowest severity that makes the command exit non-zero: low, medium, high, critical or never")
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError(fmt.Errorf("%w\nRun '%s --help' for usage", err, cmd.CommandPath()))
+	})
 }
 
-func initConfig() error {
-	path := cfgFile
-	if path == "" {
-		path = config.FindConfigFile()
+// resolveFailOn sets the failure threshold from the fail_on config key,
+// which --fail-on overrides.
+func resolveFailOn() error {
+	value := AppConfig.FailOn
+	if value == "" {
+		value = failOn
+	}
+	level, err := severity.ParseThreshold(value)
+	if err != nil {
+		return usageError(fmt.Errorf("--fail-on: %w", err))
 	}
+	failOnLevel = level
+	return nil
+}
 
-	if path == "" {
-		AppConfig = config.DefaultConfig()
-		return nil
+// commandContext returns a context that is cancelled on SIGINT/SIGTERM and,
+// when --timeout is set, once the overall time limit has passed.
+func commandContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	if timeout <= 0 {
+		return ctx, stop
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
 
-	cfg, err := config.Load(path)
+// initConfig resolves the config layers: the user config file, the repo
+// config file (--config or the nearest .devopsctl.yaml), DEVOPSCTL_*
+// environment variables and finally the flags in configFlags.
+func initConfig(cmd *cobra.Command) error {
+	cfg, origins, err := config.Resolve(config.Layers{
+		Files:   configFiles(),
+		Env:     os.Environ(),
+		Profile: profileName,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	for flag, key := range configFlags {
+		f := cmd.Flags().Lookup(flag)
+		if f == nil || !f.Changed {
+			continue
+		}
+		value := f.Value.String()
+		if f.Value.Type() == "stringSlice" {
+			list, _ := cmd.Flags().GetStringSlice(flag)
+			value = strings.Join(list, ",")
+		}
+		if err := cfg.Set(key, value); err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			var fe *config.FieldError
+			if errors.As(err, &fe) {
+				return fmt.Errorf("--%s: %s", flag, fe.Msg)
+			}
+			return fmt.Errorf("--%s: %w", flag, err)
+		}
+		origins[key] = "flag --" + flag
+	}
+
 	AppConfig = cfg
+	configOrigins = origins
 	return nil
 }
+
+// configFiles returns the config files that apply, lowest precedence first:
+// the user config file and the repo config file.
+func configFiles() []string {
+	var files []string
+	if path := config.GlobalConfigFile(); path != "" {
+		files = append(files, path)
+	}
+	path := cfgFile
+	if path == "" {
+		path = config.FindConfigFile()
+	}
+	if path != "" {
+		files = append(files, path)
+	}
+	return files
+}
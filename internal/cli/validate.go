@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/kaustuvbot/devopsctl/internal/reporter"
 	terraformpkg "github.com/kaustuvbot/devopsctl/internal/terraform"
 	"github.com/spf13/cobra"
 )
@@ -21,30 +20,29 @@ var validateTerraformCmd = &cobra.Command{
 	Use:   "terraform",
 	Short: "Validate Terraform configuration",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		pipeline, err := newResultPipeline()
+		if err != nil {
+			return err
+		}
+
 		workingDir := terraformDir
 		if workingDir == "" {
 			workingDir = "."
 		}
 
-		runner := terraformpkg.NewRunner(workingDir)
-		results, err := runner.RunAllChecks()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: some checks encountered errors: %v\n", err)
-		}
+		ctx, cancel := commandContext()
+		defer cancel()
 
-		// Convert terraform.CheckResult to reporter.CheckResult
-		reportResults := make([]reporter.CheckResult, len(results))
-		for i, r := range results {
-			reportResults[i] = reporter.CheckResult{
-				CheckName:      r.CheckName,
-				Severity:      string(r.Severity),
-				ResourceID:     r.ResourceID,
-				Message:        r.Message,
-				Recommendation: r.Recommendation,
-			}
+		runner := terraformpkg.NewRunner(workingDir)
+		outcomes := runner.RunAll(ctx, checkOptions())
+		results, checksErr := outcomes.Findings(), outcomes.Err()
+		if checksErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: some checks encountered errors: %v\n", checksErr)
 		}
 
-		report := &reporter.Report{Module: "terraform", Results: reportResults}
+		// Apply severity overrides, baseline, suppressions and quiet mode
+		report := pipeline.apply("terraform", results, outcomes.Runs())
+		report.Results = filterBySeverity(report.Results, quiet)
 
 		w, err := resolveWriter(cmd)
 		if err != nil {
@@ -55,19 +53,17 @@ var validateTerraformCmd = &cobra.Command{
 		}
 
 		rep := resolveReporter()
-		if err := rep.Render(w, report); err != nil {
-			return err
+		if err := rep.Render(w, &report); err != nil {
+			return checkError(fmt.Errorf("cannot write report: %w", err))
 		}
 
-		if code := exitCodeForResults(reportResults); code > 0 {
-			os.Exit(code)
-		}
-		return nil
+		return runOutcome(report.Results, checksErr != nil)
 	},
 }
 
 func init() {
 	validateTerraformCmd.Flags().StringVar(&terraformDir, "dir", "", "path to Terraform directory (default: current directory)")
+	validateCmd.PersistentFlags().StringVar(&baselineFile, "baseline", "", "report only findings not in this baseline file")
 	validateCmd.AddCommand(validateTerraformCmd)
 	rootCmd.AddCommand(validateCmd)
 }
@@ -1,17 +1,82 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/kaustuvbot/devopsctl/internal/catalog"
+	"github.com/kaustuvbot/devopsctl/internal/severity"
 	"gopkg.in/yaml.v3"
 )
 
-// AWSConfig holds AWS-specific configuration.
+// Modules lists the module names that config sections can refer to.
+var Modules = []string{"aws", "docker", "terraform", "git"}
+
+// AWSConfig holds AWS-specific configuration. Region is the home region,
+// used for global services; Regions lists the regions that regional checks
+// scan and defaults to the home region. When Accounts is set, each account
+// is audited instead of the account of the credentials.
 type AWSConfig struct {
-	Enabled      bool   `yaml:"enabled"`
-	Region       string `yaml:"region"`
-	Profile      string `yaml:"profile"`
-	KeyAgeDays   int    `yaml:"key_age_days"`
+	Enabled         bool         `yaml:"enabled"`
+	Region          string       `yaml:"region"`
+	Regions         Regions      `yaml:"regions"`
+	Profile         string       `yaml:"profile"`
+	KeyAgeDays      int          `yaml:"key_age_days"`
+	InactiveDays    int          `yaml:"inactive_days"`
+	PasswordAgeDays int          `yaml:"password_age_days"`
+	RootUsageDays   int          `yaml:"root_usage_days"`
+	TrustedAccounts []string     `yaml:"trusted_accounts"`
+	Accounts        []AWSAccount `yaml:"accounts"`
+}
+
+// AWSAccount is an account audited by assuming RoleARN through STS with the
+// configured credentials. Regions, when set, replaces aws.regions for the
+// account.
+type AWSAccount struct {
+	ID         string  `yaml:"id"`
+	Alias      string  `yaml:"alias,omitempty"`
+	RoleARN    string  `yaml:"role_arn"`
+	ExternalID string  `yaml:"external_id,omitempty"`
+	Regions    Regions `yaml:"regions,omitempty"`
+}
+
+// Name returns the account's alias, or its ID when it has none.
+func (a AWSAccount) Name() string {
+	if a.Alias != "" {
+		return a.Alias
+	}
+	return a.ID
+}
+
+// AllRegions, as the only entry of Regions, scans every region enabled for
+// the account.
+const AllRegions = "all"
+
+// Regions lists AWS regions. In YAML it is a list, or the scalar "all".
+type Regions []string
+
+// UnmarshalYAML accepts a list of regions or a single region name.
+func (r *Regions) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*r = Regions{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*r = list
+	return nil
+}
+
+// All reports whether every enabled region should be scanned.
+func (r Regions) All() bool {
+	return len(r) == 1 && r[0] == AllRegions
 }
 
 // DockerConfig holds Docker-specific configuration.
@@ -35,26 +100,89 @@ type GitConfig struct {
 }
 
 // IgnoreConfig holds ignore patterns for check filtering.
+// Checks lists check IDs hidden everywhere; Suppressions hide narrower sets
+// of findings and record why.
 type IgnoreConfig struct {
-	Checks []string `yaml:"checks"`
+	Checks       []string      `yaml:"checks"`
+	Suppressions []Suppression `yaml:"suppressions"`
+}
+
+// DateFormat is the layout of dates in config, such as suppression expiry.
+const DateFormat = "2006-01-02"
+
+// Suppression hides findings of a check, optionally limited to a module and
+// to resources matching a glob. Reason is required. A suppression with an
+// Expires date stops applying after that day.
+type Suppression struct {
+	Check    string `yaml:"check"`
+	Module   string `yaml:"module,omitempty"`
+	Resource string `yaml:"resource,omitempty"`
+	Reason   string `yaml:"reason"`
+	Owner    string `yaml:"owner,omitempty"`
+	Expires  string `yaml:"expires,omitempty"`
+}
+
+// ExpiresAt returns the first instant at which the suppression no longer
+// applies, or the zero time if it never expires.
+func (s Suppression) ExpiresAt() (time.Time, error) {
+	if s.Expires == "" {
+		return time.Time{}, nil
+	}
+	day, err := time.Parse(DateFormat, s.Expires)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.AddDate(0, 0, 1), nil
+}
+
+// SeverityOverride remaps the severity of a check's findings. Resource is an
+// optional glob matched against the finding's resource ID and Module
+// optionally limits the override to one module.
+type SeverityOverride struct {
+	Check    string         `yaml:"check"`
+	Severity severity.Level `yaml:"severity"`
+	Resource string         `yaml:"resource,omitempty"`
+	Module   string         `yaml:"module,omitempty"`
+}
+
+// CheckConfig holds settings for a single check, keyed by check ID.
+// Enabled defaults to true.
+type CheckConfig struct {
+	Enabled *bool `yaml:"enabled"`
 }
 
 // Config represents the main configuration structure.
 type Config struct {
-	AWS       AWSConfig       `yaml:"aws"`
-	Docker    DockerConfig    `yaml:"docker"`
-	Terraform TerraformConfig `yaml:"terraform"`
-	Git       GitConfig       `yaml:"git"`
-	Ignore    IgnoreConfig    `yaml:"ignore"`
+	AWS               AWSConfig              `yaml:"aws"`
+	Docker            DockerConfig           `yaml:"docker"`
+	Terraform         TerraformConfig        `yaml:"terraform"`
+	Git               GitConfig              `yaml:"git"`
+	Ignore            IgnoreConfig           `yaml:"ignore"`
+	SeverityOverrides []SeverityOverride     `yaml:"severity_overrides"`
+	Checks            map[string]CheckConfig `yaml:"checks"`
+	// FailOn is the lowest severity that makes a command exit non-zero,
+	// or "never".
+	FailOn string `yaml:"fail_on"`
+	// FrameworkFiles lists compliance mapping files that extend or add to
+	// the built-in frameworks.
+	FrameworkFiles []string `yaml:"framework_files"`
+	// Profiles holds named overlays selected with --profile.
+	Profiles map[string]Profile `yaml:"profiles"`
+
+	// ActiveProfile is the name of the applied profile, if any.
+	ActiveProfile string `yaml:"-"`
 }
 
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
 		AWS: AWSConfig{
-			Enabled:    true,
-			Region:     "us-east-1",
-			KeyAgeDays: 90,
+			Enabled:         true,
+			Region:          "us-east-1",
+			KeyAgeDays:      90,
+			InactiveDays:    90,
+			PasswordAgeDays: 90,
+			RootUsageDays:   30,
 		},
 		Docker: DockerConfig{
 			Enabled:        true,
@@ -72,30 +200,190 @@ func DefaultConfig() *Config {
 		Ignore: IgnoreConfig{
 			Checks: []string{},
 		},
+		FailOn: "low",
 	}
 }
 
 // Load reads and parses a YAML config file. If the file does not exist,
 // default values are returned.
 func Load(path string) (*Config, error) {
-	cfg := DefaultConfig()
+	cfg, _, err := Resolve(Layers{Files: []string{path}})
+	return cfg, err
+}
 
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return cfg, nil
+// FieldError is a validation error for a single config key, such as
+// "severity_overrides[0]" or "aws.key_age_days".
+type FieldError struct {
+	Key string
+	Msg string
+}
+
+func (e *FieldError) Error() string {
+	return e.Key + ": " + e.Msg
+}
+
+func fieldError(key, format string, args ...interface{}) error {
+	return &FieldError{Key: key, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Validate reports the first invalid setting in the config as a
+// *FieldError.
+func (c *Config) Validate() error {
+	if c.FailOn != "" {
+		if _, err := severity.ParseThreshold(c.FailOn); err != nil {
+			return fieldError("fail_on", "%v", err)
+		}
+	}
+	for _, t := range []struct {
+		key   string
+		value int
+	}{
+		{"aws.key_age_days", c.AWS.KeyAgeDays},
+		{"aws.inactive_days", c.AWS.InactiveDays},
+		{"aws.password_age_days", c.AWS.PasswordAgeDays},
+		{"aws.root_usage_days", c.AWS.RootUsageDays},
+		{"git.repo_size_mb", c.Git.RepoSizeMB},
+		{"git.branch_age_days", c.Git.BranchAgeDays},
+		{"git.large_file_mb", c.Git.LargeFileMB},
+	} {
+		if t.value <= 0 {
+			return fieldError(t.key, "must be a positive number, got %d", t.value)
+		}
+	}
+	if err := validateRegions("aws.regions", c.AWS.Regions); err != nil {
+		return err
+	}
+	for i, id := range c.AWS.TrustedAccounts {
+		if !accountID.MatchString(id) {
+			return fieldError(fmt.Sprintf("aws.trusted_accounts[%d]", i), "invalid account ID %q (want 12 digits)", id)
+		}
+	}
+	seen := make(map[string]string)
+	for i, a := range c.AWS.Accounts {
+		key := fmt.Sprintf("aws.accounts[%d]", i)
+		if !accountID.MatchString(a.ID) {
+			return fieldError(key+".id", "invalid account ID %q (want 12 digits)", a.ID)
+		}
+		if !strings.HasPrefix(a.RoleARN, "arn:") || !strings.Contains(a.RoleARN, ":role/") {
+			return fieldError(key+".role_arn", "invalid role ARN %q (want arn:aws:iam::<account>:role/<name>)", a.RoleARN)
+		}
+		for _, name := range []string{a.ID, a.Alias} {
+			if name == "" {
+				continue
+			}
+			if prev, ok := seen[name]; ok {
+				return fieldError(key, "%q is already used by %s", name, prev)
+			}
+			seen[name] = key
+		}
+		if err := validateRegions(key+".regions", a.Regions); err != nil {
+			return err
+		}
+	}
+	for i, o := range c.SeverityOverrides {
+		key := fmt.Sprintf("severity_overrides[%d]", i)
+		if o.Check == "" {
+			return fieldError(key, "check is required")
+		}
+		if !severity.IsValid(string(o.Severity)) {
+			return fieldError(key+".severity", "invalid severity %q (want LOW, MEDIUM, HIGH or CRITICAL)", o.Severity)
+		}
+		if o.Module != "" && !IsModule(o.Module) {
+			return fieldError(key+".module", "unknown module %q", o.Module)
+		}
+		if c, ok := catalog.Lookup(o.Check); !ok || (o.Module != "" && c.Module != o.Module) {
+			return fieldError(key+".check", "%s", unknownCheck(o.Check, o.Module))
+		}
+	}
+	ids := make([]string, 0, len(c.Checks))
+	for id := range c.Checks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, ok := catalog.Lookup(id); !ok {
+			return fieldError("checks."+id, "%s", unknownCheck(id, ""))
+		}
+	}
+	for i, check := range c.Ignore.Checks {
+		if !matchesCheck(check, "") {
+			return fieldError(fmt.Sprintf("ignore.checks[%d]", i), "%s", unknownCheck(check, ""))
+		}
+	}
+	for i, s := range c.Ignore.Suppressions {
+		key := fmt.Sprintf("ignore.suppressions[%d]", i)
+		if s.Check == "" {
+			return fieldError(key, "check is required")
+		}
+		if s.Reason == "" {
+			return fieldError(key, "reason is required")
+		}
+		if s.Module != "" && !IsModule(s.Module) {
+			return fieldError(key+".module", "unknown module %q", s.Module)
+		}
+		if !matchesCheck(s.Check, s.Module) {
+			return fieldError(key+".check", "%s", unknownCheck(s.Check, s.Module))
+		}
+		if _, err := s.ExpiresAt(); err != nil {
+			return fieldError(key+".expires", "invalid expires %q (want YYYY-MM-DD)", s.Expires)
+		}
+	}
+	return nil
+}
+
+// accountID matches a 12-digit AWS account ID.
+var accountID = regexp.MustCompile(`^[0-9]{12}$`)
+
+func validateRegions(key string, regions Regions) error {
+	for i, region := range regions {
+		key := fmt.Sprintf("%s[%d]", key, i)
+		if region == "" {
+			return fieldError(key, "region is required")
+		}
+		if region == AllRegions && len(regions) > 1 {
+			return fieldError(key, "%q cannot be combined with other regions", AllRegions)
 		}
-		return nil, err
 	}
+	return nil
+}
 
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return nil, err
+// matchesCheck reports whether pattern matches at least one catalog check,
+// limited to module when it is set.
+func matchesCheck(pattern, module string) bool {
+	for _, c := range catalog.Match(pattern) {
+		if module == "" || c.Module == module {
+			return true
+		}
 	}
+	return false
+}
 
-	return cfg, nil
+func unknownCheck(check, module string) string {
+	if module != "" {
+		return fmt.Sprintf("no %s check matches %q (see 'devopsctl checks list --module %s')", module, check, module)
+	}
+	return fmt.Sprintf("unknown check %q (see 'devopsctl checks list')", check)
 }
 
-// FindConfigFile looks for .devopsctl.yaml in standard locations.
+// CheckEnabled reports whether the check with the given ID should run.
+func (c *Config) CheckEnabled(id string) bool {
+	cc, ok := c.Checks[id]
+	return !ok || cc.Enabled == nil || *cc.Enabled
+}
+
+// IsModule reports whether name is one of Modules.
+func IsModule(name string) bool {
+	for _, m := range Modules {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FindConfigFile looks for .devopsctl.yaml in the current directory and, in
+// a Git repository, in each parent directory up to the repository root. The
+// nearest file wins.
 func FindConfigFile() string {
 	candidates := []string{
 		".devopsctl.yaml",
@@ -108,5 +396,30 @@ func FindConfigFile() string {
 		}
 	}
 
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	var parents []string
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			// Not in a Git repository: only the current directory counts.
+			return ""
+		}
+		parents = append(parents, parent)
+		d = parent
+	}
+	for _, d := range parents {
+		for _, c := range candidates {
+			path := filepath.Join(d, c)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
+		}
+	}
 	return ""
 }
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -177,3 +180,262 @@ ignore:
 		t.Errorf("expected second ignore check to be s3-public-bucket, got %s", cfg.Ignore.Checks[1])
 	}
 }
+
+func TestLoad_SeverityOverrides(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".devopsctl.yaml")
+	configContent := `
+severity_overrides:
+  - check: s3-public-bucket
+    severity: LOW
+    resource: "public-assets-*"
+  - check: git-large-file
+    severity: MEDIUM
+    module: git
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.SeverityOverrides) != 2 {
+		t.Fatalf("expected 2 severity overrides, got %d", len(cfg.SeverityOverrides))
+	}
+	first := cfg.SeverityOverrides[0]
+	if first.Check != "s3-public-bucket" || first.Severity != "LOW" || first.Resource != "public-assets-*" {
+		t.Errorf("unexpected first override: %+v", first)
+	}
+	if cfg.SeverityOverrides[1].Module != "git" {
+		t.Errorf("expected second override module git, got %s", cfg.SeverityOverrides[1].Module)
+	}
+}
+
+func TestValidate_SeverityOverrides(t *testing.T) {
+	tests := []struct {
+		name     string
+		override SeverityOverride
+		wantErr  bool
+	}{
+		{"valid", SeverityOverride{Check: "s3-public-bucket", Severity: "HIGH"}, false},
+		{"missing check", SeverityOverride{Severity: "HIGH"}, true},
+		{"invalid severity", SeverityOverride{Check: "s3-public-bucket", Severity: "URGENT"}, true},
+		{"lowercase severity", SeverityOverride{Check: "s3-public-bucket", Severity: "high"}, true},
+		{"unknown module", SeverityOverride{Check: "s3-public-bucket", Severity: "HIGH", Module: "k8s"}, true},
+		{"unknown check", SeverityOverride{Check: "s3-public-buckets", Severity: "HIGH"}, true},
+		{"check in other module", SeverityOverride{Check: "s3-public-bucket", Severity: "HIGH", Module: "git"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.SeverityOverrides = []SeverityOverride{tt.override}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Suppressions(t *testing.T) {
+	tests := []struct {
+		name        string
+		suppression Suppression
+		wantErr     bool
+	}{
+		{"valid", Suppression{Check: "s3-versioning-disabled", Resource: "*-logs", Reason: "lifecycle", Owner: "platform", Expires: "2025-06-30"}, false},
+		{"no expiry", Suppression{Check: "s3-versioning-disabled", Reason: "lifecycle"}, false},
+		{"missing reason", Suppression{Check: "s3-versioning-disabled"}, true},
+		{"missing check", Suppression{Reason: "lifecycle"}, true},
+		{"bad date", Suppression{Check: "s3-versioning-disabled", Reason: "lifecycle", Expires: "30/06/2025"}, true},
+		{"unknown module", Suppression{Check: "s3-versioning-disabled", Reason: "lifecycle", Module: "k8s"}, true},
+		{"unknown check", Suppression{Check: "s3-versioning", Reason: "lifecycle"}, true},
+		{"glob", Suppression{Check: "dockerfile-*", Reason: "base images are pinned upstream"}, false},
+		{"glob in other module", Suppression{Check: "dockerfile-*", Reason: "lifecycle", Module: "aws"}, true},
+		{"character class", Suppression{Check: "iam-[a-z]*", Reason: "brackets are literal"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Ignore.Suppressions = []Suppression{tt.suppression}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_FailOn(t *testing.T) {
+	for _, v := range []string{"", "low", "HIGH", "never"} {
+		cfg := DefaultConfig()
+		cfg.FailOn = v
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("fail_on %q: unexpected error %v", v, err)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.FailOn = "urgent"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for invalid fail_on")
+	}
+}
+
+func TestValidate_IgnoreChecks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Ignore.Checks = []string{"iam-mfa-disabled", "git-*"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	cfg.Ignore.Checks = []string{"iam-mfa-disable"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for unknown check")
+	}
+}
+
+func TestLoad_Checks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `
+checks:
+  s3-versioning-disabled:
+    enabled: false
+  iam-mfa-disabled:
+    enabled: true
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.CheckEnabled("s3-versioning-disabled") {
+		t.Error("s3-versioning-disabled should be disabled")
+	}
+	if !cfg.CheckEnabled("iam-mfa-disabled") || !cfg.CheckEnabled("sg-ssh-open") {
+		t.Error("checks should be enabled unless disabled in config")
+	}
+
+	cfg.Checks["no-such-check"] = CheckConfig{}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for unknown check ID")
+	}
+}
+
+func TestLoad_Strict(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown key", "aws:\n  region: eu-west-1\n  key_age_day: 30\n", `:3: unknown key "key_age_day"`},
+		{"wrong type", "git:\n  repo_size_mb: big\n", ":2: cannot unmarshal"},
+		{"negative threshold", "git:\n  large_file_mb: -5\n", ":2: git.large_file_mb: must be a positive number"},
+		{"invalid severity", "severity_overrides:\n  - check: s3-public-bucket\n    severity: URGENT\n", ":3: severity_overrides[0].severity"},
+		{"missing reason", "ignore:\n  suppressions:\n    - check: sg-ssh-open\n", ":3: ignore.suppressions[0]: reason is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), path+tt.want) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, path+tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_Thresholds(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AWS.KeyAgeDays = 0
+	err := cfg.Validate()
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Key != "aws.key_age_days" {
+		t.Errorf("Validate() error = %v, want a field error for aws.key_age_days", err)
+	}
+}
+
+func TestLoad_Regions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Regions
+		all     bool
+	}{
+		{"list", "aws:\n  regions: [us-east-1, eu-west-1]\n", Regions{"us-east-1", "eu-west-1"}, false},
+		{"all", "aws:\n  regions: all\n", Regions{AllRegions}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg.AWS.Regions, tt.want) || cfg.AWS.Regions.All() != tt.all {
+				t.Errorf("aws.regions = %v, want %v", cfg.AWS.Regions, tt.want)
+			}
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.AWS.Regions = Regions{AllRegions, "us-east-1"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error when all is combined with other regions")
+	}
+}
+
+func TestValidate_Accounts(t *testing.T) {
+	valid := AWSAccount{ID: "123456789012", Alias: "prod", RoleARN: "arn:aws:iam::123456789012:role/audit"}
+	tests := []struct {
+		name     string
+		accounts []AWSAccount
+		key      string
+	}{
+		{"valid", []AWSAccount{valid, {ID: "210987654321", RoleARN: "arn:aws:iam::210987654321:role/audit"}}, ""},
+		{"short ID", []AWSAccount{{ID: "1234", RoleARN: valid.RoleARN}}, "aws.accounts[0].id"},
+		{"missing role", []AWSAccount{{ID: valid.ID}}, "aws.accounts[0].role_arn"},
+		{"duplicate alias", []AWSAccount{valid, {ID: "210987654321", Alias: "prod", RoleARN: valid.RoleARN}}, "aws.accounts[1]"},
+		{"invalid regions", []AWSAccount{{ID: valid.ID, RoleARN: valid.RoleARN, Regions: Regions{"all", "eu-west-1"}}}, "aws.accounts[0].regions[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.AWS.Accounts = tt.accounts
+			err := cfg.Validate()
+			if tt.key == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			var fe *FieldError
+			if !errors.As(err, &fe) || fe.Key != tt.key {
+				t.Errorf("Validate() error = %v, want a field error for %s", err, tt.key)
+			}
+		})
+	}
+}
+
+func TestValidate_TrustedAccounts(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AWS.TrustedAccounts = []string{"123456789012", "12345"}
+	var fe *FieldError
+	if err := cfg.Validate(); !errors.As(err, &fe) || fe.Key != "aws.trusted_accounts[1]" {
+		t.Errorf("Validate() error = %v, want a field error for aws.trusted_accounts[1]", err)
+	}
+}
@@ -0,0 +1,393 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the environment variables that set config keys. The
+// rest of the name is the key in upper case with dots replaced by
+// underscores, e.g. DEVOPSCTL_AWS_REGION sets aws.region.
+const EnvPrefix = "DEVOPSCTL_"
+
+// OriginDefault is the origin of keys that no layer sets.
+const OriginDefault = "default"
+
+// Layers lists the config files to merge, in increasing precedence, and the
+// environment to read DEVOPSCTL_* variables from, as returned by os.Environ.
+// Empty and missing paths are skipped. Profile selects a profile, applied
+// over the files and below the environment; when it is empty,
+// DEVOPSCTL_PROFILE selects one.
+type Layers struct {
+	Files   []string
+	Env     []string
+	Profile string
+}
+
+// Origins maps a config key, such as "aws.region", to where its effective
+// value came from: a file path, "env NAME" or "flag --name".
+type Origins map[string]string
+
+// Setting is the effective value of a config key and where it came from.
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Origin string `json:"origin"`
+}
+
+// Resolve merges the defaults, each file and then the environment. Keys a
+// file sets replace the value of earlier layers; lists are replaced, not
+// appended to. Each layer is validated as it is applied so an error names
+// the layer that caused it.
+func Resolve(l Layers) (*Config, Origins, error) {
+	cfg := DefaultConfig()
+	origins := Origins{}
+
+	for _, path := range l.Files {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil {
+			return nil, nil, decodeError(path, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, nil, validationError(path, doc.Content[0], err)
+		}
+		if err := cfg.validateProfiles(profilesIn(doc.Content[0])); err != nil {
+			return nil, nil, validationError(path, doc.Content[0], err)
+		}
+		recordKeys(doc.Content[0], "", path, origins)
+	}
+
+	env := make(map[string]string)
+	for _, kv := range l.Env {
+		if name, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(name, EnvPrefix) {
+			env[name] = value
+		}
+	}
+
+	profile := l.Profile
+	if profile == "" {
+		profile = env[ProfileEnv]
+	}
+	if profile != "" {
+		if err := cfg.UseProfile(profile); err != nil {
+			return nil, nil, err
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("profile %s: %w", profile, err)
+		}
+		p := cfg.Profiles[profile]
+		recordKeys(&p.node, "", "profile "+profile, origins)
+	}
+	for _, key := range settableKeys() {
+		name := EnvName(key)
+		value, ok := env[name]
+		if !ok {
+			continue
+		}
+		if err := cfg.Set(key, value); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		origins[key] = "env " + name
+	}
+
+	return cfg, origins, nil
+}
+
+// EnvName returns the environment variable that sets key.
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// yamlLine matches the "line N: " prefix of a YAML decoding error and
+// unknownField the error for a key that no config field has.
+var (
+	yamlLine     = regexp.MustCompile(`^line (\d+): `)
+	unknownField = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+)
+
+// decodeError reports every error of a strict decode as "path:line: msg",
+// e.g. a misspelt key or a string where a number is expected.
+func decodeError(path string, err error) error {
+	errs := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		errs = typeErr.Errors
+	}
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		line := ""
+		if m := yamlLine.FindStringSubmatch(e); m != nil {
+			line, e = ":"+m[1], e[len(m[0]):]
+		}
+		if m := unknownField.FindStringSubmatch(e); m != nil {
+			e = fmt.Sprintf("unknown key %q", m[1])
+		}
+		msgs[i] = path + line + ": " + e
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+// validationError prefixes err with the file and, for a *FieldError, the
+// line of its key or of the nearest enclosing key.
+func validationError(path string, root *yaml.Node, err error) error {
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	lines := make(map[string]int)
+	keyLines(root, "", lines)
+	for key := fe.Key; key != ""; key = parentKey(key) {
+		if line, ok := lines[key]; ok {
+			return fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// keyLines records the line of every key and list item under node, with
+// list items keyed as "key[i]".
+func keyLines(node *yaml.Node, prefix string, lines map[string]int) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := joinKey(prefix, node.Content[i].Value)
+			lines[key] = node.Content[i].Line
+			keyLines(node.Content[i+1], key, lines)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			key := fmt.Sprintf("%s[%d]", prefix, i)
+			lines[key] = item.Line
+			keyLines(item, key, lines)
+		}
+	}
+}
+
+// profilesIn returns the names of the profiles a config file defines.
+func profilesIn(root *yaml.Node) []string {
+	var names []string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "profiles" || root.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		profiles := root.Content[i+1]
+		for j := 0; j+1 < len(profiles.Content); j += 2 {
+			names = append(names, profiles.Content[j].Value)
+		}
+	}
+	return names
+}
+
+// parentKey strips the last ".name" or "[i]" from key.
+func parentKey(key string) string {
+	i := strings.LastIndexAny(key, ".[")
+	if i < 0 {
+		return ""
+	}
+	return key[:i]
+}
+
+// recordKeys sets the origin of every key in a YAML mapping. Mappings are
+// walked; scalars and lists are keys of their own.
+func recordKeys(node *yaml.Node, prefix, origin string, origins Origins) {
+	if node.Kind != yaml.MappingNode {
+		if prefix != "" {
+			origins[prefix] = origin
+		}
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		recordKeys(node.Content[i+1], joinKey(prefix, node.Content[i].Value), origin, origins)
+	}
+}
+
+// Settings returns the effective value and origin of every key, sorted by
+// key. Lists and nested values are shown in YAML flow style.
+func (c *Config) Settings(origins Origins) ([]Setting, error) {
+	var doc yaml.Node
+	if err := doc.Encode(c); err != nil {
+		return nil, err
+	}
+	var settings []Setting
+	var walk func(node *yaml.Node, key string) error
+	walk = func(node *yaml.Node, key string) error {
+		if node.Kind == yaml.MappingNode && len(node.Content) > 0 {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if err := walk(node.Content[i+1], joinKey(key, node.Content[i].Value)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		value := node.Value
+		if node.Kind != yaml.ScalarNode {
+			node.Style = yaml.FlowStyle
+			out, err := yaml.Marshal(node)
+			if err != nil {
+				return err
+			}
+			value = strings.TrimSpace(string(out))
+		}
+		origin, ok := origins[key]
+		if !ok {
+			origin = OriginDefault
+		}
+		settings = append(settings, Setting{Key: key, Value: value, Origin: origin})
+		return nil
+	}
+	if err := walk(&doc, ""); err != nil {
+		return nil, err
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings, nil
+}
+
+// Set parses value into the key it names. Only string, integer, boolean
+// and string list keys can be set this way; lists are comma-separated.
+func (c *Config) Set(key, value string) error {
+	field, ok := lookupField(reflect.ValueOf(c).Elem(), key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	switch {
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: invalid integer %q", key, value)
+		}
+		field.SetInt(int64(n))
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: invalid boolean %q", key, value)
+		}
+		field.SetBool(b)
+	case isStringList(field.Type()):
+		list := []string{}
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+		field.Set(reflect.ValueOf(list).Convert(field.Type()))
+	default:
+		return fmt.Errorf("config key %q cannot be set from a string", key)
+	}
+	return nil
+}
+
+// settableKeys returns the keys Set accepts, in declaration order.
+func settableKeys() []string {
+	var keys []string
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if yamlName(f) == "-" {
+				continue
+			}
+			key := joinKey(prefix, yamlName(f))
+			switch {
+			case f.Type.Kind() == reflect.Struct:
+				walk(f.Type, key)
+			case f.Type.Kind() == reflect.String, f.Type.Kind() == reflect.Int,
+				f.Type.Kind() == reflect.Bool, isStringList(f.Type):
+				keys = append(keys, key)
+			}
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+	return keys
+}
+
+// lookupField returns the struct field a dotted key names.
+func lookupField(v reflect.Value, key string) (reflect.Value, bool) {
+	for _, part := range strings.Split(key, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			if yamlName(v.Type().Field(i)) == part {
+				v = v.Field(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return reflect.Value{}, false
+		}
+	}
+	return v, true
+}
+
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(f.Name)
+	}
+	return name
+}
+
+func isStringList(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// GlobalConfigFile returns the per-user config file,
+// $XDG_CONFIG_HOME/devopsctl/config.yaml, or "" if it does not exist.
+// XDG_CONFIG_HOME defaults to ~/.config.
+func GlobalConfigFile() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	path := filepath.Join(dir, "devopsctl", "config.yaml")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolve_Layers(t *testing.T) {
+	dir := t.TempDir()
+	global := filepath.Join(dir, "global.yaml")
+	repo := filepath.Join(dir, "repo.yaml")
+	writeFile(t, global, "aws:\n  region: eu-west-1\n  profile: me\nignore:\n  checks: [git-large-file]\n")
+	writeFile(t, repo, "aws:\n  region: eu-central-1\n")
+
+	cfg, origins, err := Resolve(Layers{
+		Files: []string{global, repo, filepath.Join(dir, "missing.yaml")},
+		Env:   []string{"DEVOPSCTL_AWS_KEY_AGE_DAYS=30", "DEVOPSCTL_IGNORE_CHECKS=iam-mfa-disabled, s3-public-bucket", "OTHER=1"},
+	})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	if cfg.AWS.Region != "eu-central-1" || cfg.AWS.Profile != "me" || cfg.AWS.KeyAgeDays != 30 {
+		t.Errorf("aws = %+v", cfg.AWS)
+	}
+	if len(cfg.Ignore.Checks) != 2 || cfg.Ignore.Checks[1] != "s3-public-bucket" {
+		t.Errorf("ignore.checks = %v, want the environment list", cfg.Ignore.Checks)
+	}
+	want := map[string]string{
+		"aws.region":       repo,
+		"aws.profile":      global,
+		"aws.key_age_days": "env DEVOPSCTL_AWS_KEY_AGE_DAYS",
+		"ignore.checks":    "env DEVOPSCTL_IGNORE_CHECKS",
+	}
+	for key, origin := range want {
+		if origins[key] != origin {
+			t.Errorf("origin of %s = %q, want %q", key, origins[key], origin)
+		}
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.yaml")
+	writeFile(t, bad, "fail_on: sometimes\n")
+
+	tests := []struct {
+		name   string
+		layers Layers
+	}{
+		{"invalid file value", Layers{Files: []string{bad}}},
+		{"invalid integer", Layers{Env: []string{"DEVOPSCTL_GIT_REPO_SIZE_MB=big"}}},
+		{"invalid boolean", Layers{Env: []string{"DEVOPSCTL_AWS_ENABLED=maybe"}}},
+		{"invalid env value", Layers{Env: []string{"DEVOPSCTL_FAIL_ON=sometimes"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Resolve(tt.layers); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestSettings(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Ignore.Checks = []string{"git-large-file"}
+	settings, err := cfg.Settings(Origins{"ignore.checks": "flag --x"})
+	if err != nil {
+		t.Fatalf("Settings() error = %v", err)
+	}
+
+	byKey := map[string]Setting{}
+	for _, s := range settings {
+		byKey[s.Key] = s
+	}
+	if s := byKey["aws.region"]; s.Value != "us-east-1" || s.Origin != OriginDefault {
+		t.Errorf("aws.region = %+v", s)
+	}
+	if s := byKey["ignore.checks"]; s.Value != "[git-large-file]" || s.Origin != "flag --x" {
+		t.Errorf("ignore.checks = %+v", s)
+	}
+}
+
+func TestSet_UnknownKey(t *testing.T) {
+	if err := DefaultConfig().Set("aws.nope", "1"); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+	if err := DefaultConfig().Set("checks", "x"); err == nil {
+		t.Error("expected an error for a key that is not a scalar or list")
+	}
+}
+
+func TestFindConfigFile_WalksToGitRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, ".devopsctl.yaml"), "aws:\n  region: test\n")
+	sub := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(sub); err != nil {
+		t.Fatalf("failed to change to %s: %v", sub, err)
+	}
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("failed to restore dir: %v", err)
+		}
+	}()
+
+	got, _ := filepath.EvalSymlinks(FindConfigFile())
+	want, _ := filepath.EvalSymlinks(filepath.Join(root, ".devopsctl.yaml"))
+	if got != want {
+		t.Errorf("FindConfigFile() = %q, want %q", got, want)
+	}
+}
+
+func TestGlobalConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if got := GlobalConfigFile(); got != "" {
+		t.Errorf("GlobalConfigFile() = %q, want empty when no file exists", got)
+	}
+
+	path := filepath.Join(dir, "devopsctl", "config.yaml")
+	writeFile(t, path, "fail_on: high\n")
+	if got := GlobalConfigFile(); got != path {
+		t.Errorf("GlobalConfigFile() = %q, want %q", got, path)
+	}
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProfileEnv names the environment variable that selects a profile when
+// --profile is not given.
+const ProfileEnv = EnvPrefix + "PROFILE"
+
+// Profile is a named overlay, such as prod or sandbox, applied over the rest
+// of the config when selected. It may set aws, git, ignore and
+// severity_overrides; only the keys it sets replace the base values, and
+// lists are replaced as a whole.
+type Profile struct {
+	node yaml.Node
+}
+
+// profileOverlay is the part of the config a profile can set. Its fields
+// point into the config the profile is applied to.
+type profileOverlay struct {
+	AWS               *AWSConfig          `yaml:"aws"`
+	Git               *GitConfig          `yaml:"git"`
+	Ignore            *IgnoreConfig       `yaml:"ignore"`
+	SeverityOverrides *[]SeverityOverride `yaml:"severity_overrides"`
+}
+
+// UnmarshalYAML keeps the profile's keys for apply, rejecting unknown keys
+// and values of the wrong type.
+func (p *Profile) UnmarshalYAML(node *yaml.Node) error {
+	if err := checkKeys(node, reflect.TypeOf(profileOverlay{})); err != nil {
+		return err
+	}
+	var trial profileOverlay
+	if err := node.Decode(&trial); err != nil {
+		return err
+	}
+	p.node = *node
+	return nil
+}
+
+// MarshalYAML returns the keys the profile sets.
+func (p Profile) MarshalYAML() (interface{}, error) {
+	return &p.node, nil
+}
+
+// apply decodes the profile's keys over c.
+func (p Profile) apply(c *Config) error {
+	return p.node.Decode(&profileOverlay{
+		AWS:               &c.AWS,
+		Git:               &c.Git,
+		Ignore:            &c.Ignore,
+		SeverityOverrides: &c.SeverityOverrides,
+	})
+}
+
+// UseProfile applies the named profile over c and records it as active.
+func (c *Config) UseProfile(name string) error {
+	p, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("unknown profile %q: no profiles are defined", name)
+		}
+		return fmt.Errorf("unknown profile %q: must be one of %s", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	if err := p.apply(c); err != nil {
+		return fmt.Errorf("profiles.%s: %w", name, err)
+	}
+	c.ActiveProfile = name
+	return nil
+}
+
+// ProfileNames returns the names of the defined profiles in lexical order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateProfiles applies each profile named in a file's profiles section
+// to a copy of c and validates the result, so a mistake in a profile is
+// reported even when it is not selected.
+func (c *Config) validateProfiles(names []string) error {
+	for _, name := range names {
+		trial := *c
+		if err := trial.UseProfile(name); err != nil {
+			return err
+		}
+		if err := trial.Validate(); err != nil {
+			var fe *FieldError
+			if errors.As(err, &fe) {
+				return &FieldError{Key: "profiles." + name + "." + fe.Key, Msg: fe.Msg}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkKeys reports the first key under node that no field of typ has.
+func checkKeys(node *yaml.Node, typ reflect.Type) error {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if err := checkKeys(item, typ); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		if typ.Kind() != reflect.Struct {
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			field, ok := fieldByYAMLName(typ, key.Value)
+			if !ok {
+				return fmt.Errorf("line %d: unknown key %q", key.Line, key.Value)
+			}
+			if err := checkKeys(node.Content[i+1], field.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func fieldByYAMLName(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		if yamlName(typ.Field(i)) == name {
+			return typ.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profilesConfig = `aws:
+  region: eu-west-1
+  key_age_days: 90
+ignore:
+  checks: [git-large-file]
+profiles:
+  prod:
+    aws:
+      key_age_days: 30
+  sandbox:
+    aws:
+      key_age_days: 180
+    ignore:
+      checks: [git-stale-branch]
+`
+
+func TestResolve_Profile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, profilesConfig)
+
+	cfg, origins, err := Resolve(Layers{Files: []string{path}, Profile: "sandbox"})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if cfg.ActiveProfile != "sandbox" {
+		t.Errorf("ActiveProfile = %q, want sandbox", cfg.ActiveProfile)
+	}
+	if cfg.AWS.KeyAgeDays != 180 || cfg.AWS.Region != "eu-west-1" {
+		t.Errorf("aws = %+v, want the profile's key age and the base region", cfg.AWS)
+	}
+	if len(cfg.Ignore.Checks) != 1 || cfg.Ignore.Checks[0] != "git-stale-branch" {
+		t.Errorf("ignore.checks = %v, want the profile's list", cfg.Ignore.Checks)
+	}
+	if origins["aws.key_age_days"] != "profile sandbox" || origins["aws.region"] != path {
+		t.Errorf("origins = %v", origins)
+	}
+}
+
+func TestResolve_ProfileFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, profilesConfig)
+
+	cfg, _, err := Resolve(Layers{
+		Files: []string{path},
+		Env:   []string{"DEVOPSCTL_PROFILE=prod", "DEVOPSCTL_AWS_REGION=us-west-2"},
+	})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if cfg.ActiveProfile != "prod" || cfg.AWS.KeyAgeDays != 30 || cfg.AWS.Region != "us-west-2" {
+		t.Errorf("profile %q, aws = %+v", cfg.ActiveProfile, cfg.AWS)
+	}
+
+	// --profile takes precedence over the environment.
+	cfg, _, err = Resolve(Layers{Files: []string{path}, Env: []string{"DEVOPSCTL_PROFILE=prod"}, Profile: "sandbox"})
+	if err != nil || cfg.ActiveProfile != "sandbox" {
+		t.Errorf("ActiveProfile = %q, err = %v, want sandbox", cfg.ActiveProfile, err)
+	}
+}
+
+func TestResolve_ProfileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		want    string
+	}{
+		{"unknown profile", profilesConfig, "staging", `unknown profile "staging": must be one of prod, sandbox`},
+		{"unknown key", "profiles:\n  prod:\n    aws:\n      key_age: 30\n", "", `:4: unknown key "key_age"`},
+		{"key a profile cannot set", "profiles:\n  prod:\n    docker:\n      enabled: false\n", "", `:3: unknown key "docker"`},
+		{"invalid value", "profiles:\n  prod:\n    git:\n      large_file_mb: 0\n", "", ":4: profiles.prod.git.large_file_mb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			writeFile(t, path, tt.content)
+			_, _, err := Resolve(Layers{Files: []string{path}, Profile: tt.profile})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Resolve() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package config
+
+import _ "embed"
+
+// Schema is the JSON Schema of the config file, for editor completion and
+// validation.
+//
+//go:embed config.schema.json
+var Schema []byte
+
+// Template is a commented config file that sets every key to its default.
+//
+//go:embed init.yaml
+var Template []byte
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaustuvbot/devopsctl/internal/catalog"
+	"gopkg.in/yaml.v3"
+)
+
+type schemaNode struct {
+	Properties           map[string]*schemaNode `json:"properties"`
+	Items                *schemaNode            `json:"items"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Enum                 []string               `json:"enum"`
+	OneOf                []*schemaNode          `json:"oneOf"`
+}
+
+func TestSchemaCoversConfig(t *testing.T) {
+	var root schemaNode
+	if err := json.Unmarshal(Schema, &root); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	var walk func(typ reflect.Type, node *schemaNode, path string)
+	walk = func(typ reflect.Type, node *schemaNode, path string) {
+		switch typ.Kind() {
+		case reflect.Slice:
+			// A list may also be given as a scalar, as aws.regions is.
+			for _, alt := range node.OneOf {
+				if alt.Items != nil {
+					node = alt
+				}
+			}
+			if node.Items == nil {
+				t.Errorf("%s: schema has no items", path)
+				return
+			}
+			walk(typ.Elem(), node.Items, path+"[]")
+		case reflect.Struct:
+			if string(node.AdditionalProperties) != "false" {
+				t.Errorf("%s: schema allows unknown keys", path)
+			}
+			fields := 0
+			for i := 0; i < typ.NumField(); i++ {
+				name := yamlName(typ.Field(i))
+				if name == "-" {
+					continue
+				}
+				fields++
+				child, ok := node.Properties[name]
+				if !ok {
+					t.Errorf("%s: schema is missing %q", path, name)
+					continue
+				}
+				walk(typ.Field(i).Type, child, joinKey(path, name))
+			}
+			if len(node.Properties) != fields {
+				t.Errorf("%s: schema has %d properties, config has %d", path, len(node.Properties), fields)
+			}
+		}
+	}
+	walk(reflect.TypeOf(Config{}), &root, "")
+}
+
+func TestSchemaCheckIDs(t *testing.T) {
+	var schema struct {
+		Defs map[string]schemaNode `json:"$defs"`
+	}
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, c := range catalog.All() {
+		ids = append(ids, c.ID)
+	}
+	if got := schema.Defs["checkID"].Enum; !reflect.DeepEqual(got, ids) {
+		t.Errorf("schema check IDs = %v, want the catalog IDs %v", got, ids)
+	}
+}
+
+func TestTemplateMatchesDefaults(t *testing.T) {
+	// Uncomment every key of the template; "##" lines are prose.
+	var b strings.Builder
+	for _, line := range strings.Split(string(Template), "\n") {
+		if strings.HasPrefix(line, "##") || strings.Contains(line, "yaml-language-server") {
+			continue
+		}
+		b.WriteString(strings.TrimPrefix(line, "# ") + "\n")
+	}
+
+	cfg := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader([]byte(b.String())))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil {
+		t.Fatalf("uncommented template does not decode: %v\n%s", err, b.String())
+	}
+	want := DefaultConfig()
+	want.Checks = map[string]CheckConfig{}
+	want.Ignore.Suppressions = []Suppression{}
+	want.SeverityOverrides = []SeverityOverride{}
+	want.FrameworkFiles = []string{}
+	want.AWS.Regions = Regions{}
+	want.AWS.TrustedAccounts = []string{}
+	want.AWS.Accounts = []AWSAccount{}
+	want.Profiles = map[string]Profile{}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("template = %+v, want defaults %+v", cfg, want)
+	}
+}
@@ -1,8 +1,10 @@
 package docker
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
@@ -10,48 +12,56 @@ import (
 // RunOptions controls optional Docker audit behaviors.
 type RunOptions struct {
 	ImageName string // If set, also run Trivy scan against this image
+	Checks    check.Options
 }
 
-// RunAll executes all Dockerfile static checks and optional Trivy scan.
-// Returns all findings aggregated into a single []reporter.CheckResult.
-// Checks that fail are skipped, not fatal - returns partial results.
-func RunAll(cfg appconfig.DockerConfig, opts RunOptions) ([]reporter.CheckResult, error) {
-	var all []reporter.CheckResult
-	var errs []string
+// Target is what Docker checks run against.
+type Target struct {
+	Dockerfile *ParsedDockerfile
+	ImageName  string
+}
 
+// Checks returns every Docker check. A Dockerfile or image without findings
+// is recorded as passing the check.
+func Checks() []check.Check[Target] {
+	static := func(id string, fn func(*ParsedDockerfile) []reporter.CheckResult, passed string) check.Check[Target] {
+		return check.New(id, func(ctx context.Context, t Target) ([]reporter.CheckResult, error) {
+			results := fn(t.Dockerfile)
+			if len(results) == 0 {
+				check.Pass(ctx, id, t.Dockerfile.Path, passed)
+			}
+			return results, nil
+		})
+	}
+	return []check.Check[Target]{
+		static("dockerfile-latest-tag", CheckLatestTag, "Every FROM image is pinned to a tag or digest"),
+		static("dockerfile-runs-as-root", CheckNoUser, "Dockerfile sets a non-root USER"),
+		static("dockerfile-no-healthcheck", CheckNoHealthcheck, "Dockerfile has a HEALTHCHECK instruction"),
+		static("dockerfile-no-multi-stage", CheckNoMultiStage, "Dockerfile uses a multi-stage build"),
+		static("dockerfile-risky-expose", CheckRiskyExpose, "Dockerfile exposes no sensitive service ports"),
+		check.New("trivy-image-vuln", func(ctx context.Context, t Target) ([]reporter.CheckResult, error) {
+			if t.ImageName == "" {
+				return nil, check.Skip("no image given")
+			}
+			results, err := ScanImage(t.ImageName)
+			if err == nil && len(results) == 0 {
+				check.Pass(ctx, "trivy-image-vuln", t.ImageName, "Image has no HIGH or CRITICAL vulnerabilities")
+			}
+			return results, err
+		}),
+	}
+}
+
+// RunAll executes all Dockerfile static checks and optional Trivy scan.
+// The Dockerfile must parse; after that, checks that fail are not fatal
+// and their errors are reported in the outcomes.
+func RunAll(ctx context.Context, cfg appconfig.DockerConfig, opts RunOptions) (check.Outcomes, error) {
 	// Parse Dockerfile first - this is a prerequisite check
 	df, err := ParseDockerfile(cfg.DockerfilePath)
 	if err != nil {
 		return nil, fmt.Errorf("docker audit: %w", err)
 	}
 
-	// Run static checks - collect errors, continue on failure
-	type checkFn func() []reporter.CheckResult
-	checks := []checkFn{
-		func() []reporter.CheckResult { return CheckLatestTag(df) },
-		func() []reporter.CheckResult { return CheckNoUser(df) },
-		func() []reporter.CheckResult { return CheckNoHealthcheck(df) },
-		func() []reporter.CheckResult { return CheckNoMultiStage(df) },
-		func() []reporter.CheckResult { return CheckRiskyExpose(df) },
-	}
-
-	for _, check := range checks {
-		results := check()
-		all = append(all, results...)
-	}
-
-	// Optional Trivy scan
-	if opts.ImageName != "" {
-		trivyResults, err := ScanImage(opts.ImageName)
-		if err != nil {
-			errs = append(errs, err.Error())
-		} else {
-			all = append(all, trivyResults...)
-		}
-	}
-
-	if len(errs) > 0 {
-		return all, fmt.Errorf("some checks failed: %v", errs)
-	}
-	return all, nil
+	target := Target{Dockerfile: df, ImageName: opts.ImageName}
+	return check.Run(ctx, target, Checks(), opts.Checks), nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os/exec"
 
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
@@ -34,10 +35,10 @@ func IsTrivyInstalled() bool {
 }
 
 // ScanImage runs trivy against an image name and returns HIGH/CRITICAL findings.
-// Returns nil results (not an error) if trivy is not installed — graceful degradation.
+// If trivy is not installed the check is skipped rather than failed.
 func ScanImage(imageName string) ([]reporter.CheckResult, error) {
 	if !IsTrivyInstalled() {
-		return nil, nil
+		return nil, check.Skip("trivy not installed")
 	}
 
 	cmd := exec.Command("trivy", "image",
@@ -0,0 +1,37 @@
+package doctor
+
+import (
+	"time"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// NewDocument assembles module reports and their summary into a single
+// document. GeneratedAt is filled in when meta leaves it unset.
+func NewDocument(reports []ModuleReport, meta reporter.Metadata) *reporter.Document {
+	if meta.GeneratedAt.IsZero() {
+		meta.GeneratedAt = time.Now().UTC()
+	}
+
+	modules := make([]reporter.Report, 0, len(reports))
+	for _, r := range reports {
+		modules = append(modules, reporter.Report{
+			Module:     r.Module,
+			Status:     string(r.Status),
+			Results:    r.Results,
+			Suppressed: r.Suppressed,
+			Resolved:   r.Resolved,
+			Checks:     r.Checks,
+			Accounts:   reporter.CountAccounts(r.Accounts, r.Results),
+			Error:      r.Error,
+			SkipReason: r.SkipReason,
+			Duration:   r.Duration,
+		})
+	}
+
+	return &reporter.Document{
+		Metadata: meta,
+		Modules:  modules,
+		Summary:  ComputeSummary(reports),
+	}
+}
@@ -0,0 +1,48 @@
+package doctor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+func TestNewDocument(t *testing.T) {
+	reports := []ModuleReport{
+		{
+			Module:   "aws",
+			Status:   StatusOK,
+			Duration: 2 * time.Second,
+			Results: []reporter.CheckResult{
+				{CheckName: "check1", Severity: "HIGH"},
+				{CheckName: "check2", Severity: "LOW"},
+			},
+		},
+		{Module: "docker", Status: StatusSkipped, SkipReason: "no Dockerfile"},
+		{Module: "git", Status: StatusFailed, Error: "git log: exit status 128"},
+	}
+
+	doc := NewDocument(reports, reporter.Metadata{Tool: "devopsctl", Version: "1.2.3", Command: "doctor"})
+
+	if doc.Metadata.GeneratedAt.IsZero() {
+		t.Error("expected GeneratedAt to be set")
+	}
+	if doc.Metadata.Version != "1.2.3" {
+		t.Errorf("expected version 1.2.3, got %s", doc.Metadata.Version)
+	}
+	if len(doc.Modules) != 3 {
+		t.Fatalf("expected 3 modules, got %d", len(doc.Modules))
+	}
+	if doc.Modules[0].Status != "ok" || doc.Modules[0].Duration != 2*time.Second {
+		t.Errorf("expected aws status and duration to be copied, got %+v", doc.Modules[0])
+	}
+	if doc.Modules[1].SkipReason != "no Dockerfile" {
+		t.Errorf("expected docker skip reason, got %q", doc.Modules[1].SkipReason)
+	}
+	if doc.Summary.TotalFindings != 2 || doc.Summary.Score != 4 {
+		t.Errorf("expected 2 findings with score 4, got %d / %d", doc.Summary.TotalFindings, doc.Summary.Score)
+	}
+	if doc.Summary.ModuleErrors["git"] == "" {
+		t.Error("expected git error in summary")
+	}
+}
@@ -2,27 +2,75 @@ package doctor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
+// Status describes how a module run ended.
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusFailed   Status = "failed"
+	StatusTimedOut Status = "timed-out"
+	StatusSkipped  Status = "skipped"
+)
+
 // ModuleReport holds results from a single module execution.
 type ModuleReport struct {
-	Module  string                  `json:"module"`
-	Results []reporter.CheckResult  `json:"results"`
-	Error   string                  `json:"error,omitempty"`
+	Module     string                      `json:"module"`
+	Status     Status                      `json:"status"`
+	Results    []reporter.CheckResult      `json:"results"`
+	Suppressed []reporter.SuppressedResult `json:"suppressed,omitempty"`
+	Resolved   []reporter.CheckResult      `json:"resolved,omitempty"`
+	Checks     []reporter.CheckRun         `json:"checks,omitempty"`
+	Accounts   []reporter.AccountSummary   `json:"accounts,omitempty"`
+	Error      string                      `json:"error,omitempty"`
+	SkipReason string                      `json:"skip_reason,omitempty"`
+	StartedAt  time.Time                   `json:"started_at"`
+	Duration   time.Duration               `json:"duration_ns"`
+}
+
+// Options controls how the engine schedules modules.
+type Options struct {
+	// Concurrency is the maximum number of modules running at once.
+	// Values below 1 run modules one at a time.
+	Concurrency int
+	// ModuleTimeout bounds a single module run. Zero disables the limit.
+	ModuleTimeout time.Duration
+}
+
+// DefaultOptions returns the scheduling options used by NewEngine.
+func DefaultOptions() Options {
+	return Options{
+		Concurrency:   4,
+		ModuleTimeout: 5 * time.Minute,
+	}
 }
 
 // Engine orchestrates all registered modules.
 type Engine struct {
 	registry *Registry
+	opts     Options
 }
 
-// NewEngine creates a new doctor engine.
+// NewEngine creates a new doctor engine with default options.
 func NewEngine() *Engine {
+	return NewEngineWithOptions(DefaultOptions())
+}
+
+// NewEngineWithOptions creates a new doctor engine with the given options.
+func NewEngineWithOptions(opts Options) *Engine {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
 	return &Engine{
 		registry: NewRegistry(),
+		opts:     opts,
 	}
 }
 
@@ -31,27 +79,72 @@ func (e *Engine) Register(m Module) error {
 	return e.registry.Register(m)
 }
 
-// RunAll executes all registered modules and returns aggregated results.
+// RunAll executes all registered modules concurrently and returns their reports
+// in registration order. A module starts only after the modules it depends on
+// have finished; if any of them did not succeed, the module is skipped.
+// When ctx is cancelled or its deadline passes, modules still running are
+// abandoned and recorded as failed or timed out; completed reports are kept.
 func (e *Engine) RunAll(ctx context.Context) ([]ModuleReport, error) {
-	var reports []ModuleReport
+	plan, err := e.registry.Plan()
+	if err != nil {
+		return nil, err
+	}
+
 	moduleNames := e.registry.List()
+	index := make(map[string]int, len(moduleNames))
+	done := make(map[string]chan struct{}, len(moduleNames))
+	for i, name := range moduleNames {
+		index[name] = i
+		done[name] = make(chan struct{})
+	}
+	reports := make([]ModuleReport, len(moduleNames))
 
-	for _, name := range moduleNames {
+	sem := make(chan struct{}, e.opts.Concurrency)
+	var wg sync.WaitGroup
+
+	for _, name := range plan {
 		module, ok := e.registry.Get(name)
 		if !ok {
 			continue
 		}
 
-		report := ModuleReport{Module: name}
+		wg.Add(1)
+		go func(name string, module Module) {
+			defer wg.Done()
+			defer close(done[name])
+			i := index[name]
 
-		results, err := module.Run(ctx)
-		if err != nil {
-			report.Error = err.Error()
-			// Continue running other modules despite failure
-		}
-		report.Results = results
-		reports = append(reports, report)
+			// Dependencies are waited on before taking a concurrency slot,
+			// so a waiting module never blocks its own prerequisites.
+			for _, dep := range e.registry.Dependencies(name) {
+				<-done[dep]
+				if status := reports[index[dep]].Status; status != StatusOK {
+					reports[i] = ModuleReport{
+						Module:     name,
+						Status:     StatusSkipped,
+						SkipReason: fmt.Sprintf("dependency %s did not succeed (%s)", dep, status),
+					}
+					return
+				}
+			}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				reports[i] = ModuleReport{
+					Module:     name,
+					Status:     StatusSkipped,
+					SkipReason: "run cancelled before module started",
+				}
+				return
+			}
+			reports[i] = e.runModule(ctx, name, module)
+		}(name, module)
 	}
+	wg.Wait()
 
 	// Check if any module failed
 	var errs []string
@@ -61,7 +154,6 @@ func (e *Engine) RunAll(ctx context.Context) ([]ModuleReport, error) {
 		}
 	}
 
-	var err error
 	if len(errs) > 0 {
 		err = fmt.Errorf("some modules failed: %v", errs)
 	}
@@ -69,6 +161,82 @@ func (e *Engine) RunAll(ctx context.Context) ([]ModuleReport, error) {
 	return reports, err
 }
 
+// runModule executes a single module under the per-module timeout.
+// A module that ignores cancellation is abandoned once its context is done.
+func (e *Engine) runModule(ctx context.Context, name string, module Module) ModuleReport {
+	report := ModuleReport{Module: name, StartedAt: time.Now()}
+
+	runCtx := ctx
+	if e.opts.ModuleTimeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, e.opts.ModuleTimeout)
+		defer cancel()
+	}
+
+	type outcome struct {
+		results  []reporter.CheckResult
+		checks   []reporter.CheckRun
+		accounts []reporter.AccountSummary
+		err      error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		results, err := module.Run(runCtx)
+		out := outcome{results: results, err: err}
+		if cr, ok := module.(CheckReporter); ok {
+			out.checks = cr.CheckRuns()
+		}
+		if ar, ok := module.(AccountReporter); ok {
+			out.accounts = ar.AccountSummaries()
+		}
+		done <- out
+	}()
+
+	var err error
+	finish := func(out outcome) {
+		report.Results = out.results
+		report.Checks = out.checks
+		report.Accounts = out.accounts
+		err = out.err
+	}
+	select {
+	case out := <-done:
+		finish(out)
+	case <-runCtx.Done():
+		// select picks at random when both are ready: a module that
+		// finished right at the deadline keeps its results.
+		select {
+		case out := <-done:
+			finish(out)
+		default:
+			err = runCtx.Err()
+		}
+	}
+	report.Duration = time.Since(report.StartedAt)
+
+	if reason, ok := skipReason(err); ok {
+		report.Status = StatusSkipped
+		report.SkipReason = reason
+		return report
+	}
+
+	switch {
+	case err == nil:
+		report.Status = StatusOK
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		report.Status = StatusTimedOut
+		report.Error = fmt.Sprintf("timed out after %s", report.Duration.Round(time.Millisecond))
+	case errors.Is(runCtx.Err(), context.Canceled):
+		report.Status = StatusFailed
+		report.Error = "cancelled"
+	default:
+		// Continue running other modules despite failure
+		report.Status = StatusFailed
+		report.Error = err.Error()
+	}
+	return report
+}
+
 // Registry returns the underlying registry.
 func (e *Engine) Registry() *Registry {
 	return e.registry
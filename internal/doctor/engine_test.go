@@ -3,16 +3,19 @@ package doctor
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
 
 // mockModule implements Module for testing
 type mockModule struct {
-	name     string
-	results  []reporter.CheckResult
-	err      error
+	name    string
+	results []reporter.CheckResult
+	err     error
 }
 
 func (m *mockModule) Name() string { return m.name }
@@ -106,9 +109,9 @@ func TestEnginePartialFailure(t *testing.T) {
 		t.Fatalf("failed to register success module: %v", err)
 	}
 	if err := engine.Register(&mockModule{
-		name: "failure",
+		name:    "failure",
 		results: nil,
-		err:   errors.New("module error"),
+		err:     errors.New("module error"),
 	}); err != nil {
 		t.Fatalf("failed to register failure module: %v", err)
 	}
@@ -149,3 +152,397 @@ func TestEngineNoModules(t *testing.T) {
 		t.Errorf("Expected 0 reports, got %d", len(reports))
 	}
 }
+
+// TestEngineSkippedModule tests that skipped modules are not reported as failures
+func TestEngineSkippedModule(t *testing.T) {
+	engine := NewEngine()
+
+	if err := engine.Register(&mockModule{
+		name: "skipped",
+		err:  Skip("disabled in config"),
+	}); err != nil {
+		t.Fatalf("failed to register skipped module: %v", err)
+	}
+
+	reports, err := engine.RunAll(context.Background())
+	if err != nil {
+		t.Errorf("Expected no error for skipped module, got %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d", len(reports))
+	}
+	if reports[0].SkipReason != "disabled in config" {
+		t.Errorf("Expected skip reason 'disabled in config', got %q", reports[0].SkipReason)
+	}
+	if reports[0].Error != "" {
+		t.Errorf("Expected no error message for skipped module, got %q", reports[0].Error)
+	}
+}
+
+// slowModule implements Module and takes a fixed time to run.
+// When ignoreCtx is set it keeps running after cancellation.
+type slowModule struct {
+	name      string
+	delay     time.Duration
+	ignoreCtx bool
+	running   *int32
+	maxSeen   *int32
+}
+
+func (m *slowModule) Name() string { return m.name }
+
+func (m *slowModule) Run(ctx context.Context) ([]reporter.CheckResult, error) {
+	if m.running != nil {
+		n := atomic.AddInt32(m.running, 1)
+		defer atomic.AddInt32(m.running, -1)
+		for {
+			seen := atomic.LoadInt32(m.maxSeen)
+			if n <= seen || atomic.CompareAndSwapInt32(m.maxSeen, seen, n) {
+				break
+			}
+		}
+	}
+
+	if m.ignoreCtx {
+		time.Sleep(m.delay)
+		return []reporter.CheckResult{{CheckName: m.name, Severity: "LOW"}}, nil
+	}
+	select {
+	case <-time.After(m.delay):
+		return []reporter.CheckResult{{CheckName: m.name, Severity: "LOW"}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// TestEngineRunsModulesConcurrently tests that modules overlap in time
+func TestEngineRunsModulesConcurrently(t *testing.T) {
+	engine := NewEngineWithOptions(Options{Concurrency: 4})
+	for _, name := range []string{"a", "b", "c", "d"} {
+		if err := engine.Register(&slowModule{name: name, delay: 100 * time.Millisecond}); err != nil {
+			t.Fatalf("failed to register %s: %v", name, err)
+		}
+	}
+
+	start := time.Now()
+	reports, err := engine.RunAll(context.Background())
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reports) != 4 {
+		t.Fatalf("Expected 4 reports, got %d", len(reports))
+	}
+	if elapsed >= 350*time.Millisecond {
+		t.Errorf("Expected modules to run in parallel, took %v", elapsed)
+	}
+	for _, r := range reports {
+		if r.Status != StatusOK {
+			t.Errorf("Expected module %s status ok, got %s", r.Module, r.Status)
+		}
+		if r.Duration <= 0 || r.StartedAt.IsZero() {
+			t.Errorf("Expected module %s to record start time and duration", r.Module)
+		}
+	}
+}
+
+// TestEngineConcurrencyLimit tests that no more than Concurrency modules run at once
+func TestEngineConcurrencyLimit(t *testing.T) {
+	var running, maxSeen int32
+	engine := NewEngineWithOptions(Options{Concurrency: 2})
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		if err := engine.Register(&slowModule{name: name, delay: 20 * time.Millisecond, running: &running, maxSeen: &maxSeen}); err != nil {
+			t.Fatalf("failed to register %s: %v", name, err)
+		}
+	}
+
+	if _, err := engine.RunAll(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if maxSeen > 2 {
+		t.Errorf("Expected at most 2 concurrent modules, saw %d", maxSeen)
+	}
+}
+
+// TestEngineModuleTimeout tests that a module ignoring its context is abandoned
+func TestEngineModuleTimeout(t *testing.T) {
+	engine := NewEngineWithOptions(Options{Concurrency: 2, ModuleTimeout: 50 * time.Millisecond})
+	if err := engine.Register(&slowModule{name: "fast", delay: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Register(&slowModule{name: "stuck", delay: time.Second, ignoreCtx: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	reports, err := engine.RunAll(context.Background())
+	if time.Since(start) >= 500*time.Millisecond {
+		t.Errorf("Expected engine to abandon stuck module, took %v", time.Since(start))
+	}
+	if err == nil {
+		t.Error("Expected error due to timed-out module")
+	}
+
+	statuses := map[string]Status{}
+	for _, r := range reports {
+		statuses[r.Module] = r.Status
+	}
+	if statuses["fast"] != StatusOK {
+		t.Errorf("Expected fast module ok, got %s", statuses["fast"])
+	}
+	if statuses["stuck"] != StatusTimedOut {
+		t.Errorf("Expected stuck module timed-out, got %s", statuses["stuck"])
+	}
+}
+
+// TestEngineCancellationKeepsPartialResults tests that completed modules survive cancellation
+func TestEngineCancellationKeepsPartialResults(t *testing.T) {
+	engine := NewEngineWithOptions(Options{Concurrency: 2})
+	if err := engine.Register(&slowModule{name: "first", delay: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Register(&slowModule{name: "second", delay: time.Second}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	reports, _ := engine.RunAll(ctx)
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 reports, got %d", len(reports))
+	}
+
+	var completed int
+	for _, r := range reports {
+		if r.Status == StatusOK && len(r.Results) == 1 {
+			completed++
+		}
+	}
+	if completed != 1 {
+		t.Errorf("Expected exactly 1 completed module with results, got %d: %+v", completed, reports)
+	}
+}
+
+// dependentModule implements Module and Dependent for testing
+type dependentModule struct {
+	mockModule
+	deps []string
+}
+
+func (m *dependentModule) DependsOn() []string { return m.deps }
+
+// TestRegistryListOrder tests that modules are listed in registration order
+func TestRegistryListOrder(t *testing.T) {
+	registry := NewRegistry()
+	names := []string{"terraform", "aws", "git", "docker", "zeta", "alpha"}
+	for _, name := range names {
+		if err := registry.Register(&mockModule{name: name}); err != nil {
+			t.Fatalf("failed to register %s: %v", name, err)
+		}
+	}
+
+	for run := 0; run < 5; run++ {
+		got := registry.List()
+		for i := range names {
+			if got[i] != names[i] {
+				t.Fatalf("Expected order %v, got %v", names, got)
+			}
+		}
+	}
+}
+
+// TestEngineReportOrder tests that reports follow registration order
+func TestEngineReportOrder(t *testing.T) {
+	engine := NewEngine()
+	names := []string{"aws", "docker", "terraform", "git"}
+	for _, name := range names {
+		if err := engine.Register(&mockModule{name: name}); err != nil {
+			t.Fatalf("failed to register %s: %v", name, err)
+		}
+	}
+
+	reports, err := engine.RunAll(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i, r := range reports {
+		if r.Module != names[i] {
+			t.Errorf("Expected report %d to be %s, got %s", i, names[i], r.Module)
+		}
+	}
+}
+
+// TestRegistryPlanDependencies tests that dependencies are planned first
+func TestRegistryPlanDependencies(t *testing.T) {
+	registry := NewRegistry()
+	modules := []Module{
+		&dependentModule{mockModule: mockModule{name: "drift"}, deps: []string{"aws", "terraform"}},
+		&mockModule{name: "terraform"},
+		&mockModule{name: "aws"},
+	}
+	for _, m := range modules {
+		if err := registry.Register(m); err != nil {
+			t.Fatalf("failed to register %s: %v", m.Name(), err)
+		}
+	}
+
+	plan, err := registry.Plan()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := []string{"aws", "terraform", "drift"}
+	if len(plan) != len(want) {
+		t.Fatalf("Expected plan %v, got %v", want, plan)
+	}
+	for i := range want {
+		if plan[i] != want[i] {
+			t.Fatalf("Expected plan %v, got %v", want, plan)
+		}
+	}
+}
+
+// TestRegistryPlanCycle tests dependency cycle detection
+func TestRegistryPlanCycle(t *testing.T) {
+	registry := NewRegistry()
+	modules := []Module{
+		&dependentModule{mockModule: mockModule{name: "a"}, deps: []string{"b"}},
+		&dependentModule{mockModule: mockModule{name: "b"}, deps: []string{"c"}},
+		&dependentModule{mockModule: mockModule{name: "c"}, deps: []string{"a"}},
+	}
+	for _, m := range modules {
+		if err := registry.Register(m); err != nil {
+			t.Fatalf("failed to register %s: %v", m.Name(), err)
+		}
+	}
+
+	_, err := registry.Plan()
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("Expected ErrDependencyCycle, got %v", err)
+	}
+	if want := "a -> b -> c -> a"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected cycle %q in error, got %v", want, err)
+	}
+}
+
+// TestRegistryPlanUnknownDependency tests that missing prerequisites are rejected
+func TestRegistryPlanUnknownDependency(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(&dependentModule{mockModule: mockModule{name: "drift"}, deps: []string{"aws"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := registry.Plan(); !errors.Is(err, ErrUnknownDependency) {
+		t.Errorf("Expected ErrUnknownDependency, got %v", err)
+	}
+}
+
+// TestEngineSkipsDependentsOfFailedModule tests that a failed prerequisite skips dependents
+func TestEngineSkipsDependentsOfFailedModule(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.Register(&mockModule{name: "aws", err: errors.New("access denied")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Register(&dependentModule{
+		mockModule: mockModule{name: "drift", results: []reporter.CheckResult{{CheckName: "check1", Severity: "LOW"}}},
+		deps:       []string{"aws"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Register(&dependentModule{mockModule: mockModule{name: "report"}, deps: []string{"drift"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, _ := engine.RunAll(context.Background())
+	if len(reports) != 3 {
+		t.Fatalf("Expected 3 reports, got %d", len(reports))
+	}
+	if reports[0].Status != StatusFailed {
+		t.Errorf("Expected aws to fail, got %s", reports[0].Status)
+	}
+	for _, r := range reports[1:] {
+		if r.Status != StatusSkipped {
+			t.Errorf("Expected %s to be skipped, got %s", r.Module, r.Status)
+		}
+		if len(r.Results) != 0 {
+			t.Errorf("Expected skipped module %s to have no results", r.Module)
+		}
+	}
+}
+
+// TestEngineRejectsCycle tests that RunAll refuses to run a cyclic plan
+func TestEngineRejectsCycle(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.Register(&dependentModule{mockModule: mockModule{name: "a"}, deps: []string{"a"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := engine.RunAll(context.Background())
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("Expected ErrDependencyCycle, got %v", err)
+	}
+	if reports != nil {
+		t.Errorf("Expected no reports, got %v", reports)
+	}
+}
+
+// checkModule implements Module and CheckReporter for testing
+type checkModule struct {
+	mockModule
+	runs []reporter.CheckRun
+}
+
+func (m *checkModule) CheckRuns() []reporter.CheckRun { return m.runs }
+
+// TestEngineCollectsCheckRuns tests that check runs reach the module report
+func TestEngineCollectsCheckRuns(t *testing.T) {
+	engine := NewEngine()
+	runs := []reporter.CheckRun{
+		{Check: "git-repo-size", Status: reporter.CheckPassed},
+		{Check: "git-stale-branch", Status: reporter.CheckSkipped, SkipReason: "disabled in config"},
+	}
+	if err := engine.Register(&checkModule{mockModule: mockModule{name: "git"}, runs: runs}); err != nil {
+		t.Fatalf("failed to register module: %v", err)
+	}
+
+	reports, err := engine.RunAll(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(reports[0].Checks) != 2 {
+		t.Fatalf("Expected 2 check runs, got %+v", reports[0].Checks)
+	}
+}
+
+// accountModule implements Module and AccountReporter for testing
+type accountModule struct {
+	mockModule
+	accounts []reporter.AccountSummary
+}
+
+func (m *accountModule) AccountSummaries() []reporter.AccountSummary { return m.accounts }
+
+// TestEngineCollectsAccounts tests that account summaries reach the module
+// report and are counted in the document
+func TestEngineCollectsAccounts(t *testing.T) {
+	engine := NewEngine()
+	module := &accountModule{
+		mockModule: mockModule{name: "aws", results: []reporter.CheckResult{
+			{CheckName: "iam-mfa-disabled", Severity: "HIGH", Account: "111111111111", ResourceID: "admin"},
+		}},
+		accounts: []reporter.AccountSummary{{ID: "111111111111", Alias: "prod"}, {ID: "222222222222"}},
+	}
+	if err := engine.Register(module); err != nil {
+		t.Fatalf("failed to register module: %v", err)
+	}
+
+	reports, err := engine.RunAll(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	doc := NewDocument(reports, reporter.Metadata{})
+	accounts := doc.Modules[0].Accounts
+	if len(accounts) != 2 || accounts[0].High != 1 || accounts[1].Findings != 0 {
+		t.Errorf("Expected prod to have one HIGH finding, got %+v", accounts)
+	}
+}
@@ -2,6 +2,8 @@ package doctor
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
@@ -15,9 +17,29 @@ type Module interface {
 	Run(ctx context.Context) ([]reporter.CheckResult, error)
 }
 
-// Registry holds registered modules.
+// Dependent is optionally implemented by modules that need other modules
+// to complete successfully before they run.
+type Dependent interface {
+	// DependsOn returns the names of prerequisite modules
+	DependsOn() []string
+}
+
+// CheckReporter is optionally implemented by modules that record the status
+// of each check they ran. CheckRuns is called after Run returns.
+type CheckReporter interface {
+	CheckRuns() []reporter.CheckRun
+}
+
+// AccountReporter is optionally implemented by modules that audit several
+// cloud accounts. AccountSummaries is called after Run returns.
+type AccountReporter interface {
+	AccountSummaries() []reporter.AccountSummary
+}
+
+// Registry holds registered modules in registration order.
 type Registry struct {
 	modules map[string]Module
+	order   []string
 }
 
 // NewRegistry creates a new module registry.
@@ -40,6 +62,7 @@ func (r *Registry) Register(m Module) error {
 		return ErrModuleAlreadyRegistered
 	}
 	r.modules[name] = m
+	r.order = append(r.order, name)
 	return nil
 }
 
@@ -49,12 +72,10 @@ func (r *Registry) Get(name string) (Module, bool) {
 	return m, ok
 }
 
-// List returns all registered module names.
+// List returns all registered module names in registration order.
 func (r *Registry) List() []string {
-	names := make([]string, 0, len(r.modules))
-	for name := range r.modules {
-		names = append(names, name)
-	}
+	names := make([]string, len(r.order))
+	copy(names, r.order)
 	return names
 }
 
@@ -63,11 +84,80 @@ func (r *Registry) Len() int {
 	return len(r.modules)
 }
 
+// Dependencies returns the prerequisite module names declared by a module.
+func (r *Registry) Dependencies(name string) []string {
+	if d, ok := r.modules[name].(Dependent); ok {
+		return d.DependsOn()
+	}
+	return nil
+}
+
+// Plan returns module names in an order where every module follows its
+// dependencies. Ties are broken by registration order, so the plan is stable.
+// It fails on dependencies that are not registered and on dependency cycles.
+func (r *Registry) Plan() ([]string, error) {
+	for _, name := range r.order {
+		for _, dep := range r.Dependencies(name) {
+			if _, ok := r.modules[dep]; !ok {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrUnknownDependency, name, dep)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(r.order))
+	plan := make([]string, 0, len(r.order))
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for i, n := range path {
+				if n == name {
+					start = i
+					break
+				}
+			}
+			cycle := append(append([]string{}, path[start:]...), name)
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range r.Dependencies(name) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		plan = append(plan, name)
+		return nil
+	}
+
+	for _, name := range r.order {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
 // Errors for registry operations
 var (
-	ErrNilModule           = &RegistryError{"nil module not allowed"}
-	ErrEmptyModuleName     = &RegistryError{"module name cannot be empty"}
+	ErrNilModule               = &RegistryError{"nil module not allowed"}
+	ErrEmptyModuleName         = &RegistryError{"module name cannot be empty"}
 	ErrModuleAlreadyRegistered = &RegistryError{"module already registered"}
+	ErrUnknownDependency       = &RegistryError{"unknown module dependency"}
+	ErrDependencyCycle         = &RegistryError{"module dependency cycle"}
 )
 
 type RegistryError struct {
@@ -1,28 +1,34 @@
 package doctor
 
 import (
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
 	"github.com/kaustuvbot/devopsctl/internal/severity"
 )
 
 // Summary holds aggregated scoring information.
-type Summary struct {
-	TotalFindings int            `json:"total_findings"`
-	Critical      int            `json:"critical"`
-	High          int            `json:"high"`
-	Medium        int            `json:"medium"`
-	Low           int            `json:"low"`
-	Score         int            `json:"score"`
-	ModulesFailed int            `json:"modules_failed"`
-	ModuleErrors  map[string]string `json:"module_errors,omitempty"`
-}
+type Summary = reporter.Summary
 
 // ComputeSummary calculates aggregate statistics from module reports.
 func ComputeSummary(reports []ModuleReport) Summary {
 	summary := Summary{
-		ModuleErrors: make(map[string]string),
+		ModuleErrors:   make(map[string]string),
+		SkippedModules: make(map[string]string),
 	}
 
+	var coverage reporter.Coverage
 	for _, report := range reports {
+		summary.ResolvedFindings += len(report.Resolved)
+		coverage = coverage.Add(reporter.NewCoverage(report.Checks))
+		if report.SkipReason != "" {
+			summary.ModulesSkipped++
+			summary.SkippedModules[report.Module] = report.SkipReason
+			continue
+		}
+		if report.Status == StatusTimedOut {
+			summary.ModulesTimedOut++
+			summary.ModuleErrors[report.Module] = report.Error
+			continue
+		}
 		if report.Error != "" {
 			summary.ModulesFailed++
 			summary.ModuleErrors[report.Module] = report.Error
@@ -49,6 +55,9 @@ func ComputeSummary(reports []ModuleReport) Summary {
 		}
 	}
 
+	if coverage.Total > 0 {
+		summary.Coverage = &coverage
+	}
 	return summary
 }
 
@@ -203,3 +203,44 @@ func TestHighestSeverity(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeSummary_SkippedModules(t *testing.T) {
+	reports := []ModuleReport{
+		{Module: "aws", SkipReason: "no AWS credentials configured"},
+		{Module: "git", Results: []reporter.CheckResult{{CheckName: "check1", Severity: "LOW"}}},
+	}
+
+	summary := ComputeSummary(reports)
+
+	if summary.ModulesSkipped != 1 {
+		t.Errorf("expected 1 module skipped, got %d", summary.ModulesSkipped)
+	}
+	if summary.ModulesFailed != 0 {
+		t.Errorf("expected 0 modules failed, got %d", summary.ModulesFailed)
+	}
+	if summary.SkippedModules["aws"] != "no AWS credentials configured" {
+		t.Errorf("expected skip reason for aws, got %q", summary.SkippedModules["aws"])
+	}
+}
+
+func TestComputeSummary_Coverage(t *testing.T) {
+	reports := []ModuleReport{
+		{Module: "aws", Checks: []reporter.CheckRun{
+			{Check: "s3-public-bucket", Status: reporter.CheckFailed},
+			{Check: "iam-mfa-disabled", Status: reporter.CheckSkipped},
+		}},
+		{Module: "git", Checks: []reporter.CheckRun{
+			{Check: "git-repo-size", Status: reporter.CheckErrored},
+		}},
+	}
+
+	summary := ComputeSummary(reports)
+
+	want := reporter.Coverage{Total: 3, Executed: 1, Skipped: 1, Errored: 1}
+	if summary.Coverage == nil || *summary.Coverage != want {
+		t.Errorf("expected coverage %+v, got %+v", want, summary.Coverage)
+	}
+	if ComputeSummary(nil).Coverage != nil {
+		t.Error("expected no coverage without check runs")
+	}
+}
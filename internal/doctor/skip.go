@@ -0,0 +1,28 @@
+package doctor
+
+import "errors"
+
+// SkipError signals that a module could not run in the current environment,
+// for example because it is disabled in config or its inputs are missing.
+// The engine records skipped modules separately from failed ones.
+type SkipError struct {
+	Reason string
+}
+
+func (e *SkipError) Error() string {
+	return "skipped: " + e.Reason
+}
+
+// Skip returns an error that marks a module as skipped with the given reason.
+func Skip(reason string) error {
+	return &SkipError{Reason: reason}
+}
+
+// skipReason returns the reason if err is a SkipError.
+func skipReason(err error) (string, bool) {
+	var skip *SkipError
+	if errors.As(err, &skip) {
+		return skip.Reason, true
+	}
+	return "", false
+}
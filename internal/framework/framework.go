@@ -0,0 +1,261 @@
+// Package framework maps compliance framework controls, such as those of the
+// CIS AWS Foundations Benchmark, to the checks that evaluate them, and
+// evaluates a run's results control by control.
+package framework
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/kaustuvbot/devopsctl/internal/catalog"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed frameworks/*.yaml
+var builtin embed.FS
+
+// Control is a framework control and the checks that evaluate it. A control
+// without checks is listed so reports can show it as not covered.
+type Control struct {
+	ID     string   `yaml:"id" json:"id"`
+	Title  string   `yaml:"title" json:"title"`
+	Checks []string `yaml:"checks,omitempty" json:"checks,omitempty"`
+}
+
+// Framework is a set of controls, as read from a mapping file.
+type Framework struct {
+	ID       string    `yaml:"id" json:"id"`
+	Name     string    `yaml:"name" json:"name"`
+	Controls []Control `yaml:"controls" json:"controls"`
+}
+
+// Ref names a control of a framework.
+type Ref struct {
+	Framework string `json:"framework"`
+	Control   string `json:"control"`
+}
+
+// Set holds the known frameworks by ID.
+type Set struct {
+	frameworks map[string]*Framework
+}
+
+// Load returns the built-in frameworks extended by the mapping files at
+// paths. A file whose ID matches a known framework adds controls to it, or
+// checks to controls it already has; any other file adds a framework.
+func Load(paths ...string) (*Set, error) {
+	s := &Set{frameworks: map[string]*Framework{}}
+
+	entries, err := fs.ReadDir(builtin, "frameworks")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		data, err := builtin.ReadFile("frameworks/" + e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if err := s.add(e.Name(), data); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read framework file: %w", err)
+		}
+		if err := s.add(path, data); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// add parses a mapping file and merges it into the set.
+func (s *Set) add(name string, data []byte) error {
+	var f Framework
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if err := f.validate(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	existing, ok := s.frameworks[f.ID]
+	if !ok {
+		s.frameworks[f.ID] = &f
+		return nil
+	}
+	if f.Name != "" {
+		existing.Name = f.Name
+	}
+	for _, c := range f.Controls {
+		existing.merge(c)
+	}
+	return nil
+}
+
+// merge adds c to the framework, or its title and checks to the control
+// with the same ID.
+func (f *Framework) merge(c Control) {
+	for i := range f.Controls {
+		ctl := &f.Controls[i]
+		if ctl.ID != c.ID {
+			continue
+		}
+		if c.Title != "" {
+			ctl.Title = c.Title
+		}
+		for _, id := range c.Checks {
+			if !contains(ctl.Checks, id) {
+				ctl.Checks = append(ctl.Checks, id)
+			}
+		}
+		return
+	}
+	f.Controls = append(f.Controls, c)
+}
+
+// validate reports the first missing field, duplicate control or unknown
+// check in a mapping file.
+func (f *Framework) validate() error {
+	if f.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	seen := map[string]bool{}
+	for i, c := range f.Controls {
+		if c.ID == "" {
+			return fmt.Errorf("controls[%d]: id is required", i)
+		}
+		if seen[c.ID] {
+			return fmt.Errorf("controls[%d]: duplicate control %q", i, c.ID)
+		}
+		seen[c.ID] = true
+		for _, id := range c.Checks {
+			if _, ok := catalog.Lookup(id); !ok {
+				return fmt.Errorf("control %s: unknown check %q (see 'devopsctl checks list')", c.ID, id)
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns the framework with the given ID.
+func (s *Set) Get(id string) (*Framework, bool) {
+	f, ok := s.frameworks[id]
+	return f, ok
+}
+
+// IDs returns the IDs of every framework in lexical order.
+func (s *Set) IDs() []string {
+	ids := make([]string, 0, len(s.frameworks))
+	for id := range s.frameworks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ControlsFor returns the controls that checkID evaluates, ordered by
+// framework and then as listed in the framework.
+func (s *Set) ControlsFor(checkID string) []Ref {
+	var refs []Ref
+	for _, id := range s.IDs() {
+		for _, c := range s.frameworks[id].Controls {
+			if contains(c.Checks, checkID) {
+				refs = append(refs, Ref{Framework: id, Control: c.ID})
+			}
+		}
+	}
+	return refs
+}
+
+// Evaluate groups results by control. runs records which checks ran, and may
+// hold several runs of a check, e.g. one per module of a document. A control
+// whose checks did not all run fully, in every run, is not covered unless one
+// of them has findings.
+func (f *Framework) Evaluate(runs []reporter.CheckRun, results []reporter.CheckResult) *reporter.Compliance {
+	runsByCheck := make(map[string][]reporter.CheckRun, len(runs))
+	for _, run := range runs {
+		runsByCheck[run.Check] = append(runsByCheck[run.Check], run)
+	}
+	byCheck := make(map[string][]reporter.CheckResult)
+	for _, r := range results {
+		byCheck[r.CheckName] = append(byCheck[r.CheckName], r)
+	}
+
+	out := &reporter.Compliance{Framework: f.ID, Name: f.Name, Controls: []reporter.ControlResult{}}
+	for _, c := range f.Controls {
+		cr := reporter.ControlResult{ID: c.ID, Title: c.Title, Checks: c.Checks}
+
+		var failing, notRun []string
+		for _, id := range c.Checks {
+			if found := byCheck[id]; len(found) > 0 {
+				failing = append(failing, id)
+				cr.Findings += len(found)
+				for _, r := range found {
+					if !contains(cr.Resources, r.ResourceID) {
+						cr.Resources = append(cr.Resources, r.ResourceID)
+					}
+				}
+				continue
+			}
+			if len(runsByCheck[id]) == 0 {
+				notRun = append(notRun, id+" not run")
+			}
+			for _, run := range runsByCheck[id] {
+				var gap string
+				switch {
+				case run.Status == reporter.CheckSkipped:
+					gap = fmt.Sprintf("%s skipped: %s", id, run.SkipReason)
+				case run.Status == reporter.CheckErrored:
+					gap = fmt.Sprintf("%s errored: %s", id, run.Error)
+				case run.Partial != "":
+					gap = fmt.Sprintf("%s partial coverage: %s", id, run.Partial)
+				}
+				if gap != "" && !contains(notRun, gap) {
+					notRun = append(notRun, gap)
+				}
+			}
+		}
+
+		switch {
+		case len(c.Checks) == 0:
+			cr.Status = reporter.ControlNotCovered
+			cr.Detail = "no automated check"
+			out.NotCovered++
+		case len(failing) > 0:
+			cr.Status = reporter.ControlFail
+			cr.Detail = fmt.Sprintf("%d finding(s) from %s", cr.Findings, strings.Join(failing, ", "))
+			out.Failed++
+		case len(notRun) > 0:
+			cr.Status = reporter.ControlNotCovered
+			cr.Detail = strings.Join(notRun, "; ")
+			out.NotCovered++
+		default:
+			cr.Status = reporter.ControlPass
+			cr.Detail = "passed " + strings.Join(c.Checks, ", ")
+			out.Passed++
+		}
+		out.Controls = append(out.Controls, cr)
+	}
+	return out
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+func TestLoadBuiltin(t *testing.T) {
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	for _, id := range []string{"cis-aws-1.5", "cis-docker-1.6", "soc2"} {
+		f, ok := s.Get(id)
+		if !ok {
+			t.Errorf("framework %s not found", id)
+			continue
+		}
+		if f.Name == "" || len(f.Controls) == 0 {
+			t.Errorf("framework %s = %+v, want a name and controls", id, f)
+		}
+	}
+
+	refs := s.ControlsFor("iam-mfa-disabled")
+	want := []Ref{{Framework: "cis-aws-1.5", Control: "1.10"}, {Framework: "soc2", Control: "CC6.1"}}
+	if len(refs) != len(want) {
+		t.Fatalf("ControlsFor() = %+v, want %+v", refs, want)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Errorf("ControlsFor()[%d] = %+v, want %+v", i, refs[i], want[i])
+		}
+	}
+}
+
+func TestLoadExtends(t *testing.T) {
+	dir := t.TempDir()
+	extra := filepath.Join(dir, "extra.yaml")
+	data := `id: cis-aws-1.5
+controls:
+  - id: "1.15"
+    checks: [iam-admin-access]
+  - id: "9.9"
+    title: Custom control
+    checks: [ebs-unattached]
+`
+	if err := os.WriteFile(extra, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	internal := filepath.Join(dir, "internal.yaml")
+	if err := os.WriteFile(internal, []byte("id: internal\nname: Internal policy\ncontrols:\n  - id: P1\n    title: Volumes are encrypted\n    checks: [ebs-unencrypted]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(extra, internal)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	f, _ := s.Get("cis-aws-1.5")
+	if f.Name != "CIS Amazon Web Services Foundations Benchmark v1.5.0" {
+		t.Errorf("name = %q, want the built-in name kept", f.Name)
+	}
+	var found1_15, found9_9 bool
+	for _, c := range f.Controls {
+		switch c.ID {
+		case "1.15":
+			found1_15 = len(c.Checks) == 1 && c.Checks[0] == "iam-admin-access" && c.Title != ""
+		case "9.9":
+			found9_9 = c.Title == "Custom control"
+		}
+	}
+	if !found1_15 || !found9_9 {
+		t.Errorf("controls not merged: %+v", f.Controls)
+	}
+	if _, ok := s.Get("internal"); !ok {
+		t.Error("new framework not added")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]struct {
+		data string
+		want string
+	}{
+		"unknown check": {"id: x\ncontrols:\n  - id: A\n    checks: [no-such-check]\n", `unknown check "no-such-check"`},
+		"unknown field": {"id: x\ncontrol:\n  - id: A\n", "field control not found"},
+		"missing id":    {"controls:\n  - id: A\n", "id is required"},
+		"duplicate":     {"id: x\ncontrols:\n  - id: A\n  - id: A\n", `duplicate control "A"`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "f.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	f := &Framework{ID: "test", Name: "Test", Controls: []Control{
+		{ID: "1", Title: "manual"},
+		{ID: "2", Title: "fails", Checks: []string{"iam-mfa-disabled", "iam-admin-access"}},
+		{ID: "3", Title: "passes", Checks: []string{"ebs-unencrypted"}},
+		{ID: "4", Title: "skipped", Checks: []string{"ebs-unencrypted", "s3-public-bucket"}},
+		{ID: "5", Title: "not run", Checks: []string{"terraform-fmt"}},
+		{ID: "6", Title: "skipped in one run", Checks: []string{"sg-ssh-open"}},
+		{ID: "7", Title: "partial", Checks: []string{"s3-no-encryption"}},
+	}}
+	runs := []reporter.CheckRun{
+		{Check: "iam-mfa-disabled", Status: reporter.CheckFailed, Findings: 2},
+		{Check: "iam-admin-access", Status: reporter.CheckSkipped, SkipReason: "AccessDenied on iam:ListUsers"},
+		{Check: "ebs-unencrypted", Status: reporter.CheckPassed},
+		{Check: "s3-public-bucket", Status: reporter.CheckSkipped, SkipReason: "AccessDenied on s3:ListAllMyBuckets"},
+		{Check: "sg-ssh-open", Status: reporter.CheckSkipped, SkipReason: "AccessDenied on ec2:DescribeSecurityGroups"},
+		{Check: "sg-ssh-open", Status: reporter.CheckPassed},
+		{Check: "s3-no-encryption", Status: reporter.CheckPassed, Partial: "3 of 7 buckets denied: AccessDenied on s3:GetEncryptionConfiguration"},
+	}
+	results := []reporter.CheckResult{
+		{CheckName: "iam-mfa-disabled", ResourceID: "alice"},
+		{CheckName: "iam-mfa-disabled", ResourceID: "bob"},
+	}
+
+	c := f.Evaluate(runs, results)
+
+	want := []string{reporter.ControlNotCovered, reporter.ControlFail, reporter.ControlPass, reporter.ControlNotCovered, reporter.ControlNotCovered,
+		reporter.ControlNotCovered, reporter.ControlNotCovered}
+	for i, ctl := range c.Controls {
+		if ctl.Status != want[i] {
+			t.Errorf("control %s: status = %s, want %s (%s)", ctl.ID, ctl.Status, want[i], ctl.Detail)
+		}
+	}
+	if c.Passed != 1 || c.Failed != 1 || c.NotCovered != 5 {
+		t.Errorf("counts = %d/%d/%d, want 1/1/5", c.Passed, c.Failed, c.NotCovered)
+	}
+	if ctl := c.Controls[1]; ctl.Findings != 2 || len(ctl.Resources) != 2 {
+		t.Errorf("failing control = %+v", ctl)
+	}
+	if ctl := c.Controls[3]; ctl.Detail != "s3-public-bucket skipped: AccessDenied on s3:ListAllMyBuckets" {
+		t.Errorf("skipped control detail = %q", ctl.Detail)
+	}
+	if ctl := c.Controls[4]; ctl.Detail != "terraform-fmt not run" {
+		t.Errorf("not run control detail = %q", ctl.Detail)
+	}
+	// A run that completed does not cover for one that was skipped.
+	if ctl := c.Controls[5]; ctl.Detail != "sg-ssh-open skipped: AccessDenied on ec2:DescribeSecurityGroups" {
+		t.Errorf("control skipped in one run: detail = %q", ctl.Detail)
+	}
+	if ctl := c.Controls[6]; ctl.Detail != "s3-no-encryption partial coverage: 3 of 7 buckets denied: AccessDenied on s3:GetEncryptionConfiguration" {
+		t.Errorf("partial control detail = %q", ctl.Detail)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 	"github.com/kaustuvbot/devopsctl/internal/severity"
@@ -61,6 +62,8 @@ func CheckStaleBranches(ctx context.Context, client *Client, cfg appconfig.GitCo
 				Message:        "Branch has not been updated in over " + strconv.Itoa(cfg.BranchAgeDays) + " days",
 				Recommendation: "Consider deleting stale branches or merging/updating them",
 			})
+		} else {
+			check.Pass(ctx, "git-stale-branch", branch, "Branch was updated "+strconv.Itoa(int(age.Hours()/24))+" days ago")
 		}
 	}
 
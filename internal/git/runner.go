@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 )
@@ -22,43 +23,39 @@ func NewRunner(repoPath string, cfg appconfig.GitConfig) *Runner {
 	}
 }
 
-// RunAll executes all git checks and returns aggregated results.
-func (r *Runner) RunAll(ctx context.Context) ([]reporter.CheckResult, error) {
-	var all []reporter.CheckResult
-	var errs []string
-
-	checks := []struct {
-		name string
-		fn   func(context.Context) ([]reporter.CheckResult, error)
-	}{
-		{"repo-size", func(ctx context.Context) ([]reporter.CheckResult, error) {
-			return CheckRepoSize(ctx, r.client, r.cfg)
-		}},
-		{"stale-branches", func(ctx context.Context) ([]reporter.CheckResult, error) {
-			return CheckStaleBranches(ctx, r.client, r.cfg)
-		}},
-		{"large-files", func(ctx context.Context) ([]reporter.CheckResult, error) {
-			return CheckLargeFiles(ctx, r.client, r.cfg)
-		}},
-	}
-
-	for _, check := range checks {
-		results, err := check.fn(ctx)
-		if err != nil {
-			errs = append(errs, fmt.Sprintf("%s: %v", check.name, err))
-			continue
-		}
-		all = append(all, results...)
-	}
+// IsRepo reports whether the runner's path is inside a git work tree.
+func (r *Runner) IsRepo() bool {
+	return r.client.IsRepo()
+}
 
-	if len(errs) > 0 {
-		return all, fmt.Errorf("some checks failed: %v", errs)
+// Checks returns every git check, configured by cfg.
+func Checks(cfg appconfig.GitConfig) []check.Check[*Client] {
+	return []check.Check[*Client]{
+		check.New("git-repo-size", func(ctx context.Context, c *Client) ([]reporter.CheckResult, error) {
+			return CheckRepoSize(ctx, c, cfg)
+		}),
+		check.New("git-stale-branch", func(ctx context.Context, c *Client) ([]reporter.CheckResult, error) {
+			return CheckStaleBranches(ctx, c, cfg)
+		}),
+		check.New("git-large-file", func(ctx context.Context, c *Client) ([]reporter.CheckResult, error) {
+			// Tracked files are too many to list one by one, so the
+			// repository is the resource that passes.
+			results, err := CheckLargeFiles(ctx, c, cfg)
+			if err == nil && len(results) == 0 {
+				check.Pass(ctx, "git-large-file", c.repoPath, fmt.Sprintf("No tracked file exceeds %d MB", cfg.LargeFileMB))
+			}
+			return results, err
+		}),
 	}
+}
 
-	return all, nil
+// RunAll executes all git checks and returns their outcomes.
+func (r *Runner) RunAll(ctx context.Context, opts check.Options) check.Outcomes {
+	return check.Run(ctx, r.client, Checks(r.cfg), opts)
 }
 
 // RunAllSimple is a convenience method without context.
 func (r *Runner) RunAllSimple() ([]reporter.CheckResult, error) {
-	return r.RunAll(context.Background())
+	outcomes := r.RunAll(context.Background(), check.Options{})
+	return outcomes.Findings(), outcomes.Err()
 }
@@ -78,3 +78,12 @@ func TestCheckResultSeverity(t *testing.T) {
 		}
 	}
 }
+
+// TestRunnerIsRepo tests detection of directories outside a git work tree
+func TestRunnerIsRepo(t *testing.T) {
+	cfg := appconfig.GitConfig{RepoSizeMB: 500, BranchAgeDays: 90, LargeFileMB: 50}
+	runner := NewRunner(t.TempDir(), cfg)
+	if runner.IsRepo() {
+		t.Error("Expected temp directory not to be a git repository")
+	}
+}
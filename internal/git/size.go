@@ -2,10 +2,12 @@ package git
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	appconfig "github.com/kaustuvbot/devopsctl/internal/config"
 	"github.com/kaustuvbot/devopsctl/internal/reporter"
 	"github.com/kaustuvbot/devopsctl/internal/severity"
@@ -50,6 +52,7 @@ func CheckRepoSize(ctx context.Context, client *Client, cfg appconfig.GitConfig)
 		}, nil
 	}
 
+	check.Pass(ctx, "git-repo-size", client.repoPath, fmt.Sprintf("Repository size %.1f MB is within the %d MB threshold", sizeMB, cfg.RepoSizeMB))
 	return nil, nil
 }
 
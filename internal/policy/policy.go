@@ -0,0 +1,149 @@
+// Package policy applies the result-shaping rules from config, such as
+// severity overrides and suppressions, to check results before they are
+// filtered, scored and reported.
+package policy
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/kaustuvbot/devopsctl/internal/catalog"
+	"github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
+
+// Policy holds the rules applied to every module's results.
+type Policy struct {
+	overrides    []override
+	suppressions []suppression
+	expired      []config.Suppression
+}
+
+type override struct {
+	config.SeverityOverride
+	resource *regexp.Regexp
+}
+
+type suppression struct {
+	config.Suppression
+	check    *regexp.Regexp
+	resource *regexp.Regexp
+}
+
+// New builds a Policy from the loaded config, evaluating suppression
+// expiry against the current time.
+func New(cfg *config.Config) *Policy {
+	return newAt(cfg, time.Now())
+}
+
+func newAt(cfg *config.Config, now time.Time) *Policy {
+	p := &Policy{}
+	for _, o := range cfg.SeverityOverrides {
+		entry := override{SeverityOverride: o}
+		if o.Resource != "" {
+			entry.resource = catalog.Glob(o.Resource)
+		}
+		p.overrides = append(p.overrides, entry)
+	}
+
+	for _, check := range cfg.Ignore.Checks {
+		p.suppressions = append(p.suppressions, suppression{
+			Suppression: config.Suppression{Check: check, Reason: "listed in ignore.checks"},
+			check:       catalog.Glob(check),
+		})
+	}
+	for _, s := range cfg.Ignore.Suppressions {
+		// Expired suppressions stop applying so their findings resurface.
+		if expires, err := s.ExpiresAt(); err == nil && !expires.IsZero() && !now.Before(expires) {
+			p.expired = append(p.expired, s)
+			continue
+		}
+		entry := suppression{Suppression: s, check: catalog.Glob(s.Check)}
+		if s.Resource != "" {
+			entry.resource = catalog.Glob(s.Resource)
+		}
+		p.suppressions = append(p.suppressions, entry)
+	}
+	return p
+}
+
+// Expired returns the configured suppressions that have expired.
+func (p *Policy) Expired() []config.Suppression {
+	return p.expired
+}
+
+// Suppress splits a module's results into findings to report and findings
+// hidden by a suppression. The first matching suppression is recorded.
+func (p *Policy) Suppress(module string, results []reporter.CheckResult) ([]reporter.CheckResult, []reporter.SuppressedResult) {
+	if len(p.suppressions) == 0 {
+		return results, nil
+	}
+	var kept []reporter.CheckResult
+	var suppressed []reporter.SuppressedResult
+	for _, r := range results {
+		s, ok := p.suppression(module, r)
+		if !ok {
+			kept = append(kept, r)
+			continue
+		}
+		suppressed = append(suppressed, reporter.SuppressedResult{
+			CheckResult: r,
+			Reason:      s.Reason,
+			Owner:       s.Owner,
+			Expires:     s.Expires,
+		})
+	}
+	return kept, suppressed
+}
+
+func (p *Policy) suppression(module string, r reporter.CheckResult) (suppression, bool) {
+	for _, s := range p.suppressions {
+		if !s.check.MatchString(r.CheckName) {
+			continue
+		}
+		if s.Module != "" && s.Module != module {
+			continue
+		}
+		if s.resource != nil && !s.resource.MatchString(r.ResourceID) {
+			continue
+		}
+		return s, true
+	}
+	return suppression{}, false
+}
+
+// Apply returns the module's results with severity overrides applied.
+// The first matching override wins. Remapped results keep their original
+// severity in OriginalSeverity. The input slice is not modified.
+func (p *Policy) Apply(module string, results []reporter.CheckResult) []reporter.CheckResult {
+	if len(p.overrides) == 0 || len(results) == 0 {
+		return results
+	}
+	out := make([]reporter.CheckResult, len(results))
+	for i, r := range results {
+		if o, ok := p.match(module, r); ok && string(o.Severity) != r.Severity {
+			if r.OriginalSeverity == "" {
+				r.OriginalSeverity = r.Severity
+			}
+			r.Severity = string(o.Severity)
+		}
+		out[i] = r
+	}
+	return out
+}
+
+func (p *Policy) match(module string, r reporter.CheckResult) (override, bool) {
+	for _, o := range p.overrides {
+		if o.Check != r.CheckName {
+			continue
+		}
+		if o.Module != "" && o.Module != module {
+			continue
+		}
+		if o.resource != nil && !o.resource.MatchString(r.ResourceID) {
+			continue
+		}
+		return o, true
+	}
+	return override{}, false
+}
@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaustuvbot/devopsctl/internal/config"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+	"github.com/kaustuvbot/devopsctl/internal/severity"
+)
+
+func TestApplySeverityOverrides(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SeverityOverrides = []config.SeverityOverride{
+		{Check: "s3-public-bucket", Severity: severity.Low, Resource: "public-assets-*"},
+		{Check: "s3-public-bucket", Severity: severity.High},
+		{Check: "git-large-file", Severity: severity.Low, Module: "git", Resource: "testdata/*"},
+		{Check: "dockerfile-latest-tag", Severity: severity.Medium},
+	}
+	p := New(cfg)
+
+	tests := []struct {
+		name         string
+		module       string
+		result       reporter.CheckResult
+		wantSeverity string
+		wantOriginal string
+	}{
+		{
+			name:         "resource glob matches first",
+			module:       "aws",
+			result:       reporter.CheckResult{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "public-assets-prod"},
+			wantSeverity: "LOW",
+			wantOriginal: "CRITICAL",
+		},
+		{
+			name:         "falls through to unscoped override",
+			module:       "aws",
+			result:       reporter.CheckResult{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "customer-data"},
+			wantSeverity: "HIGH",
+			wantOriginal: "CRITICAL",
+		},
+		{
+			name:         "glob star spans path separators",
+			module:       "git",
+			result:       reporter.CheckResult{CheckName: "git-large-file", Severity: "MEDIUM", ResourceID: "testdata/fixtures/big.bin"},
+			wantSeverity: "LOW",
+			wantOriginal: "MEDIUM",
+		},
+		{
+			name:         "module scope does not match other modules",
+			module:       "docker",
+			result:       reporter.CheckResult{CheckName: "git-large-file", Severity: "MEDIUM", ResourceID: "testdata/big.bin"},
+			wantSeverity: "MEDIUM",
+		},
+		{
+			name:         "same severity is not recorded as override",
+			module:       "docker",
+			result:       reporter.CheckResult{CheckName: "dockerfile-latest-tag", Severity: "MEDIUM", ResourceID: "Dockerfile:line1"},
+			wantSeverity: "MEDIUM",
+		},
+		{
+			name:         "unrelated check is unchanged",
+			module:       "aws",
+			result:       reporter.CheckResult{CheckName: "iam-mfa-disabled", Severity: "HIGH", ResourceID: "alice"},
+			wantSeverity: "HIGH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := []reporter.CheckResult{tt.result}
+			got := p.Apply(tt.module, in)
+			if got[0].Severity != tt.wantSeverity {
+				t.Errorf("Severity = %q, want %q", got[0].Severity, tt.wantSeverity)
+			}
+			if got[0].OriginalSeverity != tt.wantOriginal {
+				t.Errorf("OriginalSeverity = %q, want %q", got[0].OriginalSeverity, tt.wantOriginal)
+			}
+			if in[0].Severity != tt.result.Severity {
+				t.Error("Apply must not modify its input")
+			}
+		})
+	}
+}
+
+func TestApplyKeepsFirstOriginalSeverity(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SeverityOverrides = []config.SeverityOverride{{Check: "c", Severity: severity.Low}}
+
+	results := []reporter.CheckResult{{CheckName: "c", Severity: "MEDIUM", OriginalSeverity: "CRITICAL"}}
+	got := New(cfg).Apply("aws", results)
+	if got[0].OriginalSeverity != "CRITICAL" {
+		t.Errorf("OriginalSeverity = %q, want CRITICAL", got[0].OriginalSeverity)
+	}
+}
+
+func TestSuppress(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Ignore.Checks = []string{"ebs-unattached"}
+	cfg.Ignore.Suppressions = []config.Suppression{
+		{Check: "s3-versioning-disabled", Resource: "*-logs", Reason: "log buckets use lifecycle rules", Owner: "platform", Expires: "2024-06-30"},
+		{Check: "git-large-file", Module: "git", Reason: "fixtures", Owner: "qa"},
+		{Check: "sg-ssh-open", Reason: "bastion", Expires: "2024-01-31"},
+	}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	p := newAt(cfg, now)
+
+	results := []reporter.CheckResult{
+		{CheckName: "s3-versioning-disabled", ResourceID: "app-logs"},
+		{CheckName: "s3-versioning-disabled", ResourceID: "customer-data"},
+		{CheckName: "ebs-unattached", ResourceID: "vol-1"},
+		{CheckName: "sg-ssh-open", ResourceID: "sg-1"},
+	}
+	kept, suppressed := p.Suppress("aws", results)
+
+	if len(kept) != 2 || kept[0].ResourceID != "customer-data" || kept[1].CheckName != "sg-ssh-open" {
+		t.Errorf("kept = %+v, want customer-data and the expired sg-ssh-open", kept)
+	}
+	if len(suppressed) != 2 {
+		t.Fatalf("suppressed = %d, want 2", len(suppressed))
+	}
+	if s := suppressed[0]; s.ResourceID != "app-logs" || s.Reason != "log buckets use lifecycle rules" || s.Owner != "platform" || s.Expires != "2024-06-30" {
+		t.Errorf("suppressed[0] = %+v", s)
+	}
+	if s := suppressed[1]; s.CheckName != "ebs-unattached" || s.Reason != "listed in ignore.checks" {
+		t.Errorf("suppressed[1] = %+v", s)
+	}
+
+	expired := p.Expired()
+	if len(expired) != 1 || expired[0].Check != "sg-ssh-open" {
+		t.Errorf("Expired() = %+v, want sg-ssh-open", expired)
+	}
+
+	if kept, _ := p.Suppress("docker", []reporter.CheckResult{{CheckName: "git-large-file"}}); len(kept) != 1 {
+		t.Error("module-scoped suppression should not apply to other modules")
+	}
+}
+
+func TestSuppressExpiryIsInclusive(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Ignore.Suppressions = []config.Suppression{{Check: "c", Reason: "r", Expires: "2024-01-31"}}
+	results := []reporter.CheckResult{{CheckName: "c"}}
+
+	lastDay := time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)
+	if kept, _ := newAt(cfg, lastDay).Suppress("aws", results); len(kept) != 0 {
+		t.Error("suppression should still apply on its expiry date")
+	}
+	nextDay := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	if kept, _ := newAt(cfg, nextDay).Suppress("aws", results); len(kept) != 1 {
+		t.Error("suppression should not apply after its expiry date")
+	}
+}
@@ -0,0 +1,75 @@
+package reporter
+
+import "github.com/kaustuvbot/devopsctl/internal/severity"
+
+// AccountSummary is the outcome of one AWS account in a multi-account
+// audit. Error is set when the account could not be audited, for example
+// because its role could not be assumed.
+type AccountSummary struct {
+	ID       string `json:"id"`
+	Alias    string `json:"alias,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Findings int    `json:"findings"`
+	Critical int    `json:"critical"`
+	High     int    `json:"high"`
+	Medium   int    `json:"medium"`
+	Low      int    `json:"low"`
+}
+
+// Name returns the account's alias, or its ID when it has none.
+func (a AccountSummary) Name() string {
+	return accountName(a.ID, a.Alias)
+}
+
+// CountAccounts returns a copy of accounts with the findings in results
+// counted per account, so the summary matches the findings a report shows
+// after suppressions and the baseline.
+func CountAccounts(accounts []AccountSummary, results []CheckResult) []AccountSummary {
+	if len(accounts) == 0 {
+		return nil
+	}
+	out := make([]AccountSummary, len(accounts))
+	index := make(map[string]int, len(accounts))
+	for i, a := range accounts {
+		a.Findings, a.Critical, a.High, a.Medium, a.Low = 0, 0, 0, 0, 0
+		out[i] = a
+		index[a.ID] = i
+	}
+	for _, r := range results {
+		i, ok := index[r.Account]
+		if !ok {
+			continue
+		}
+		a := &out[i]
+		a.Findings++
+		switch severity.Level(r.Severity) {
+		case severity.Critical:
+			a.Critical++
+		case severity.High:
+			a.High++
+		case severity.Medium:
+			a.Medium++
+		case severity.Low:
+			a.Low++
+		}
+	}
+	return out
+}
+
+// hasAccounts reports whether any result is tagged with an account, in which
+// case renderers show the account of each finding.
+func hasAccounts(results []CheckResult) bool {
+	for _, r := range results {
+		if r.Account != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func accountName(id, alias string) string {
+	if alias != "" {
+		return alias
+	}
+	return id
+}
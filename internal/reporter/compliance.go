@@ -0,0 +1,40 @@
+package reporter
+
+import "fmt"
+
+// Control statuses in a compliance report.
+const (
+	ControlPass       = "pass"
+	ControlFail       = "fail"
+	ControlNotCovered = "not-covered"
+)
+
+// Compliance groups a run's results by the controls of a compliance
+// framework, such as CIS AWS Foundations.
+type Compliance struct {
+	Framework  string          `json:"framework"`
+	Name       string          `json:"name"`
+	Passed     int             `json:"passed"`
+	Failed     int             `json:"failed"`
+	NotCovered int             `json:"not_covered"`
+	Controls   []ControlResult `json:"controls"`
+}
+
+// ControlResult is the status of one control. A control fails when any of
+// its checks has findings, passes when all of them ran without findings,
+// and is not covered when it has no checks or some of them did not run.
+type ControlResult struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Checks    []string `json:"checks,omitempty"`
+	Findings  int      `json:"findings"`
+	Resources []string `json:"resources,omitempty"`
+	Detail    string   `json:"detail,omitempty"`
+}
+
+// complianceLine summarises control statuses, e.g.
+// "CIS ... v1.5.0: 5 pass, 2 fail, 8 not covered".
+func complianceLine(c *Compliance) string {
+	return fmt.Sprintf("%s: %d pass, %d fail, %d not covered", c.Name, c.Passed, c.Failed, c.NotCovered)
+}
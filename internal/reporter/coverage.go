@@ -0,0 +1,155 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Check statuses. A check is executed when it passed or failed.
+const (
+	CheckPassed  = "passed"
+	CheckFailed  = "failed"
+	CheckSkipped = "skipped"
+	CheckErrored = "errored"
+)
+
+// CheckRun records how a single check ended, so a report can show what was
+// actually evaluated and not only what was found.
+type CheckRun struct {
+	Check      string        `json:"check"`
+	Status     string        `json:"status"`
+	Findings   int           `json:"findings"`
+	Evaluated  int           `json:"evaluated"`
+	SkipReason string        `json:"skip_reason,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration_ns"`
+	// Partial says what an executed check could not evaluate, e.g. "3 of 7
+	// buckets denied: AccessDenied on s3:GetBucketAcl".
+	Partial string `json:"partial,omitempty"`
+	// Passed lists the resources that passed, when passed results are
+	// included. Evaluated counts distinct resources with a finding or a
+	// pass either way.
+	Passed []Evidence `json:"passed,omitempty"`
+}
+
+// Evidence records a resource that passed a check. Account is set in a
+// multi-account AWS audit.
+type Evidence struct {
+	Account    string `json:"account,omitempty"`
+	ResourceID string `json:"resource_id"`
+	Message    string `json:"message"`
+}
+
+// Coverage counts check runs by outcome.
+type Coverage struct {
+	Total    int `json:"total"`
+	Executed int `json:"executed"`
+	Skipped  int `json:"skipped"`
+	Errored  int `json:"errored"`
+}
+
+// NewCoverage counts runs by outcome.
+func NewCoverage(runs []CheckRun) Coverage {
+	c := Coverage{Total: len(runs)}
+	for _, run := range runs {
+		switch run.Status {
+		case CheckPassed, CheckFailed:
+			c.Executed++
+		case CheckSkipped:
+			c.Skipped++
+		case CheckErrored:
+			c.Errored++
+		}
+	}
+	return c
+}
+
+// Add returns the sum of two coverages.
+func (c Coverage) Add(o Coverage) Coverage {
+	return Coverage{
+		Total:    c.Total + o.Total,
+		Executed: c.Executed + o.Executed,
+		Skipped:  c.Skipped + o.Skipped,
+		Errored:  c.Errored + o.Errored,
+	}
+}
+
+// coverageLine summarises a module's check runs, e.g.
+// "7 of 9 aws checks executed; 2 skipped: AccessDenied on s3:GetBucketAcl".
+// Distinct skip reasons, the IDs of errored checks and the checks that
+// executed on only some resources are listed.
+func coverageLine(module string, runs []CheckRun) string {
+	c := NewCoverage(runs)
+	line := fmt.Sprintf("%d of %d %s checks executed", c.Executed, c.Total, module)
+
+	var reasons, errored, partial []string
+	seen := make(map[string]bool)
+	for _, run := range notCovered(runs) {
+		switch {
+		case run.Status == CheckErrored:
+			errored = append(errored, run.Check)
+		case run.Status == CheckSkipped:
+			if !seen[run.SkipReason] {
+				seen[run.SkipReason] = true
+				reasons = append(reasons, run.SkipReason)
+			}
+		default:
+			partial = append(partial, fmt.Sprintf("%s %s (%s)", run.Check, run.Status, run.Partial))
+		}
+	}
+	if c.Skipped > 0 {
+		line += fmt.Sprintf("; %d skipped: %s", c.Skipped, strings.Join(reasons, ", "))
+	}
+	if c.Errored > 0 {
+		line += fmt.Sprintf("; %d errored: %s", c.Errored, strings.Join(errored, ", "))
+	}
+	if len(partial) > 0 {
+		line += fmt.Sprintf("; %d partial: %s", len(partial), strings.Join(partial, ", "))
+	}
+	return line
+}
+
+// passedCount counts the pass records of all runs.
+func passedCount(runs []CheckRun) int {
+	n := 0
+	for _, run := range runs {
+		n += len(run.Passed)
+	}
+	return n
+}
+
+// notExecuted returns the runs that were skipped or errored.
+func notExecuted(runs []CheckRun) []CheckRun {
+	var out []CheckRun
+	for _, run := range runs {
+		if run.Status == CheckSkipped || run.Status == CheckErrored {
+			out = append(out, run)
+		}
+	}
+	return out
+}
+
+// notCovered returns the runs that were skipped or errored, or executed on
+// only some resources.
+func notCovered(runs []CheckRun) []CheckRun {
+	var out []CheckRun
+	for _, run := range runs {
+		if run.Status == CheckSkipped || run.Status == CheckErrored || run.Partial != "" {
+			out = append(out, run)
+		}
+	}
+	return out
+}
+
+// reasonOf returns why a run was not executed, or what an executed run
+// could not evaluate.
+func reasonOf(run CheckRun) string {
+	switch run.Status {
+	case CheckErrored:
+		return run.Error
+	case CheckSkipped:
+		return run.SkipReason
+	}
+	return run.Partial
+}
@@ -0,0 +1,32 @@
+package reporter
+
+import "testing"
+
+func TestCoverageLine(t *testing.T) {
+	runs := []CheckRun{
+		{Check: "s3-public-bucket", Status: CheckFailed, Findings: 2},
+		{Check: "s3-no-encryption", Status: CheckPassed, Partial: "3 of 7 buckets denied: AccessDenied on s3:GetEncryptionConfiguration"},
+		{Check: "iam-mfa-disabled", Status: CheckSkipped, SkipReason: "AccessDenied on iam:ListUsers"},
+		{Check: "iam-old-access-key", Status: CheckSkipped, SkipReason: "AccessDenied on iam:ListUsers"},
+		{Check: "ebs-unencrypted", Status: CheckErrored, Error: "throttled"},
+	}
+
+	c := NewCoverage(runs)
+	if c != (Coverage{Total: 5, Executed: 2, Skipped: 2, Errored: 1}) {
+		t.Errorf("NewCoverage() = %+v", c)
+	}
+
+	want := "2 of 5 aws checks executed; 2 skipped: AccessDenied on iam:ListUsers; 1 errored: ebs-unencrypted; " +
+		"1 partial: s3-no-encryption passed (3 of 7 buckets denied: AccessDenied on s3:GetEncryptionConfiguration)"
+	if got := coverageLine("aws", runs); got != want {
+		t.Errorf("coverageLine() = %q, want %q", got, want)
+	}
+}
+
+func TestCoverageAdd(t *testing.T) {
+	a := Coverage{Total: 3, Executed: 2, Skipped: 1}
+	b := Coverage{Total: 2, Executed: 1, Errored: 1}
+	if got := a.Add(b); got != (Coverage{Total: 5, Executed: 3, Skipped: 1, Errored: 1}) {
+		t.Errorf("Add() = %+v", got)
+	}
+}
@@ -0,0 +1,60 @@
+package reporter
+
+import (
+	"sort"
+	"time"
+)
+
+// Summary holds aggregated scoring information across modules.
+type Summary struct {
+	TotalFindings    int               `json:"total_findings"`
+	Critical         int               `json:"critical"`
+	High             int               `json:"high"`
+	Medium           int               `json:"medium"`
+	Low              int               `json:"low"`
+	Score            int               `json:"score"`
+	ResolvedFindings int               `json:"resolved_findings,omitempty"`
+	Coverage         *Coverage         `json:"coverage,omitempty"`
+	ModulesFailed    int               `json:"modules_failed"`
+	ModulesTimedOut  int               `json:"modules_timed_out"`
+	ModulesSkipped   int               `json:"modules_skipped"`
+	ModuleErrors     map[string]string `json:"module_errors,omitempty"`
+	SkippedModules   map[string]string `json:"skipped_modules,omitempty"`
+}
+
+// Metadata describes the run that produced a document.
+type Metadata struct {
+	Tool        string    `json:"tool"`
+	Version     string    `json:"version"`
+	Command     string    `json:"command"`
+	Profile     string    `json:"profile,omitempty"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Document is a complete multi-module report that reporters render
+// as a single coherent output. Compliance is set when a framework was
+// selected and covers the results of every module.
+type Document struct {
+	Metadata   Metadata    `json:"metadata"`
+	Modules    []Report    `json:"modules"`
+	Summary    Summary     `json:"summary"`
+	Compliance *Compliance `json:"compliance,omitempty"`
+}
+
+// statusOr returns status, or fallback when status is empty.
+func statusOr(status, fallback string) string {
+	if status == "" {
+		return fallback
+	}
+	return status
+}
+
+// sortedKeys returns the keys of m in lexical order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,236 @@
+package reporter
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/kaustuvbot/devopsctl/internal/severity"
+)
+
+//go:embed templates/report.html
+var htmlTemplate string
+
+var htmlReport = template.Must(template.New("report").Parse(htmlTemplate))
+
+// HTMLReporter renders a self-contained HTML report with a summary dashboard,
+// a sortable and filterable findings table and recommendations per check.
+// CSS and JavaScript are embedded so the file can be opened offline.
+type HTMLReporter struct{}
+
+// NewHTMLReporter creates a new HTMLReporter.
+func NewHTMLReporter() *HTMLReporter {
+	return &HTMLReporter{}
+}
+
+type htmlView struct {
+	Title      string
+	Version    string
+	Profile    string
+	Generated  string
+	Summary    Summary
+	Modules    []htmlModule
+	Accounts   []AccountSummary
+	Findings   []htmlFinding
+	Checks     []htmlCheck
+	Suppressed []htmlSuppressed
+	Resolved   []htmlFinding
+	Runs       []htmlCheckRun
+	Passed     []htmlPassed
+	Compliance *Compliance
+}
+
+type htmlModule struct {
+	Name     string
+	Status   string
+	Critical int
+	High     int
+	Medium   int
+	Low      int
+	Total    int
+	Duration string
+	Checks   string
+	Note     string
+}
+
+type htmlFinding struct {
+	CheckResult
+	Module string
+	Weight int
+}
+
+type htmlSuppressed struct {
+	SuppressedResult
+	Module string
+}
+
+type htmlCheckRun struct {
+	CheckRun
+	Module string
+	Reason string
+}
+
+type htmlPassed struct {
+	Evidence
+	Check  string
+	Module string
+}
+
+type htmlCheck struct {
+	CheckName       string
+	Module          string
+	Severity        string
+	Recommendations []string
+	Resources       []string
+}
+
+// Render writes a single module report as an HTML page.
+func (r *HTMLReporter) Render(w io.Writer, report *Report) error {
+	doc := &Document{
+		Metadata:   Metadata{Profile: report.Profile},
+		Modules:    []Report{*report},
+		Summary:    summarize(report.Results),
+		Compliance: report.Compliance,
+	}
+	view := newHTMLView(doc)
+	view.Title = titleCase(report.Module) + " Audit Report"
+	return htmlReport.Execute(w, view)
+}
+
+// RenderDocument writes the whole document as an HTML page.
+func (r *HTMLReporter) RenderDocument(w io.Writer, doc *Document) error {
+	return htmlReport.Execute(w, newHTMLView(doc))
+}
+
+// newHTMLView flattens a document into the data the template renders.
+func newHTMLView(doc *Document) htmlView {
+	view := htmlView{
+		Title:      "devopsctl Report",
+		Version:    doc.Metadata.Version,
+		Profile:    doc.Metadata.Profile,
+		Summary:    doc.Summary,
+		Compliance: doc.Compliance,
+	}
+	if doc.Metadata.Command != "" {
+		view.Title = "devopsctl " + titleCase(doc.Metadata.Command) + " Report"
+	}
+	if !doc.Metadata.GeneratedAt.IsZero() {
+		view.Generated = doc.Metadata.GeneratedAt.Format(time.RFC3339)
+	}
+
+	checks := make(map[string]*htmlCheck)
+	var checkOrder []string
+
+	for _, report := range doc.Modules {
+		module := htmlModule{
+			Name:   report.Module,
+			Status: statusOr(report.Status, "ok"),
+			Note:   report.Error,
+		}
+		if report.SkipReason != "" {
+			module.Note = report.SkipReason
+		}
+		if len(report.Checks) > 0 {
+			c := NewCoverage(report.Checks)
+			module.Checks = fmt.Sprintf("%d/%d", c.Executed, c.Total)
+		}
+		for _, run := range report.Checks {
+			view.Runs = append(view.Runs, htmlCheckRun{CheckRun: run, Module: report.Module, Reason: reasonOf(run)})
+			for _, p := range run.Passed {
+				view.Passed = append(view.Passed, htmlPassed{Evidence: p, Check: run.Check, Module: report.Module})
+			}
+		}
+		view.Accounts = append(view.Accounts, report.Accounts...)
+		if report.Duration > 0 {
+			module.Duration = report.Duration.Round(time.Millisecond).String()
+		}
+
+		for _, result := range report.Results {
+			module.Total++
+			switch severity.Level(result.Severity) {
+			case severity.Critical:
+				module.Critical++
+			case severity.High:
+				module.High++
+			case severity.Medium:
+				module.Medium++
+			case severity.Low:
+				module.Low++
+			}
+
+			view.Findings = append(view.Findings, htmlFinding{
+				CheckResult: result,
+				Module:      report.Module,
+				Weight:      severity.Level(result.Severity).Weight(),
+			})
+
+			key := report.Module + "/" + result.CheckName
+			check, ok := checks[key]
+			if !ok {
+				check = &htmlCheck{CheckName: result.CheckName, Module: report.Module}
+				checks[key] = check
+				checkOrder = append(checkOrder, key)
+			}
+			if severity.Level(result.Severity).Weight() > severity.Level(check.Severity).Weight() {
+				check.Severity = result.Severity
+			}
+			check.Resources = append(check.Resources, result.ResourceID)
+			if result.Recommendation != "" && !contains(check.Recommendations, result.Recommendation) {
+				check.Recommendations = append(check.Recommendations, result.Recommendation)
+			}
+		}
+		for _, r := range report.Resolved {
+			view.Resolved = append(view.Resolved, htmlFinding{CheckResult: r, Module: report.Module})
+		}
+		for _, s := range report.Suppressed {
+			view.Suppressed = append(view.Suppressed, htmlSuppressed{SuppressedResult: s, Module: report.Module})
+		}
+		view.Modules = append(view.Modules, module)
+	}
+
+	// Most severe findings and checks first; ties keep report order.
+	sort.SliceStable(view.Findings, func(i, j int) bool {
+		return view.Findings[i].Weight > view.Findings[j].Weight
+	})
+	for _, key := range checkOrder {
+		view.Checks = append(view.Checks, *checks[key])
+	}
+	sort.SliceStable(view.Checks, func(i, j int) bool {
+		return severity.Level(view.Checks[i].Severity).Weight() > severity.Level(view.Checks[j].Severity).Weight()
+	})
+	return view
+}
+
+// summarize counts findings by severity for reports rendered outside a
+// Document, using the same weights as the doctor score.
+func summarize(results []CheckResult) Summary {
+	var s Summary
+	for _, result := range results {
+		level := severity.Level(result.Severity)
+		s.TotalFindings++
+		s.Score += level.Weight()
+		switch level {
+		case severity.Critical:
+			s.Critical++
+		case severity.High:
+			s.High++
+		case severity.Medium:
+			s.Medium++
+		case severity.Low:
+			s.Low++
+		}
+	}
+	return s
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
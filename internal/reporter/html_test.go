@@ -0,0 +1,129 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTMLReporter_RenderDocument(t *testing.T) {
+	doc := &Document{
+		Metadata: Metadata{Tool: "devopsctl", Version: "1.2.3", Command: "doctor", GeneratedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		Modules: []Report{
+			{
+				Module: "aws",
+				Status: "ok",
+				Results: []CheckResult{
+					{CheckName: "iam-key-age", Severity: "LOW", ResourceID: "alice", Message: "old key", Recommendation: "Rotate keys"},
+					{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "<script>alert(1)</script>", Message: "public", Recommendation: "Block public access"},
+					{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "b2", Message: "public", Recommendation: "Block public access"},
+				},
+			},
+			{Module: "docker", Status: "skipped", SkipReason: "no Dockerfile"},
+		},
+		Summary: Summary{TotalFindings: 3, Critical: 2, Low: 1, Score: 9},
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLReporter().RenderDocument(&buf, doc); err != nil {
+		t.Fatalf("RenderDocument() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<title>devopsctl Doctor Report</title>",
+		"devopsctl 1.2.3",
+		"2024-01-02T03:04:05Z",
+		`<div class="value">9</div><div class="label">Score</div>`,
+		"no Dockerfile",
+		`id="findings-table"`,
+		`id="recommendations"`,
+		"Block public access",
+		"<style>",
+		"<script>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+
+	if strings.Contains(out, "<script>alert(1)</script>") {
+		t.Error("resource IDs must be HTML-escaped")
+	}
+	if strings.Count(out, "Block public access") != 1 {
+		t.Error("recommendations should be grouped once per check")
+	}
+	if strings.Contains(out, `src="http`) || strings.Contains(out, `href="http`) {
+		t.Error("report must not load external resources")
+	}
+
+	critical := strings.Index(out, `<tr data-severity="CRITICAL"`)
+	low := strings.Index(out, `<tr data-severity="LOW"`)
+	if critical == -1 || low == -1 || critical > low {
+		t.Error("findings should be ordered by severity, most severe first")
+	}
+}
+
+func TestHTMLReporter_Render(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []CheckResult
+		want    []string
+	}{
+		{
+			name:    "no findings",
+			results: []CheckResult{},
+			want:    []string{"<title>Git Audit Report</title>", "No findings."},
+		},
+		{
+			name: "findings are summarized",
+			results: []CheckResult{
+				{CheckName: "git-large-file", Severity: "MEDIUM", ResourceID: "big.bin", Message: "large"},
+			},
+			want: []string{`<div class="value">2</div><div class="label">Score</div>`, "big.bin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewHTMLReporter().Render(&buf, &Report{Module: "git", Results: tt.results}); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestHTMLReporter_CheckRuns(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Checks: []CheckRun{
+			{Check: "ebs-unencrypted", Status: CheckPassed, Evaluated: 3, Passed: []Evidence{{ResourceID: "vol-1", Message: "encrypted"}}},
+			{Check: "iam-mfa-disabled", Status: CheckSkipped, SkipReason: "AccessDenied on iam:ListUsers"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLReporter().Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`id="checks"`,
+		`<td class="num">3</td>`,
+		"AccessDenied on iam:ListUsers",
+		`id="passed"`,
+		"<code>vol-1</code>",
+		`<td class="num">1/2</td>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
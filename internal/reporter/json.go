@@ -17,9 +17,18 @@ func NewJSONReporter(pretty bool) *JSONReporter {
 
 // Render writes the report as JSON to the given writer.
 func (r *JSONReporter) Render(w io.Writer, report *Report) error {
+	return r.encode(w, report)
+}
+
+// RenderDocument writes the whole document as a single JSON object.
+func (r *JSONReporter) RenderDocument(w io.Writer, doc *Document) error {
+	return r.encode(w, doc)
+}
+
+func (r *JSONReporter) encode(w io.Writer, v interface{}) error {
 	encoder := json.NewEncoder(w)
 	if r.Pretty {
 		encoder.SetIndent("", "  ")
 	}
-	return encoder.Encode(report)
+	return encoder.Encode(v)
 }
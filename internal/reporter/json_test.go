@@ -159,3 +159,37 @@ func TestJSONReporter_CheckResultFields(t *testing.T) {
 		t.Error("expected 'recommendation' in JSON output")
 	}
 }
+
+func TestJSONReporter_RenderDocument(t *testing.T) {
+	doc := &Document{
+		Metadata: Metadata{Tool: "devopsctl", Version: "dev", Command: "doctor"},
+		Modules: []Report{
+			{Module: "aws", Status: "ok", Results: []CheckResult{{CheckName: "iam-mfa-disabled", Severity: "HIGH"}}},
+			{Module: "git", Status: "skipped", SkipReason: "not a git repository"},
+		},
+		Summary: Summary{TotalFindings: 1, High: 1, Score: 3, ModulesSkipped: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJSONReporter(true).RenderDocument(&buf, doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The whole document must decode as exactly one JSON value
+	var parsed map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	if err := dec.Decode(&parsed); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if dec.More() {
+		t.Error("expected a single JSON document")
+	}
+	for _, key := range []string{"metadata", "modules", "summary"} {
+		if _, ok := parsed[key]; !ok {
+			t.Errorf("expected key %q in output", key)
+		}
+	}
+	if modules, ok := parsed["modules"].([]interface{}); !ok || len(modules) != 2 {
+		t.Errorf("expected 2 modules, got %v", parsed["modules"])
+	}
+}
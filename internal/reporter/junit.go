@@ -0,0 +1,277 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kaustuvbot/devopsctl/internal/severity"
+)
+
+// JUnitReporter renders results as JUnit XML so CI systems show findings as
+// test failures. Each check is a testcase and each module a testsuite.
+type JUnitReporter struct {
+	// FailAt is the lowest severity reported as a failure. Checks whose
+	// findings are all below it are reported as passed.
+	FailAt severity.Level
+}
+
+// NewJUnitReporter creates a new JUnit reporter that fails checks with
+// findings at or above failAt.
+func NewJUnitReporter(failAt severity.Level) *JUnitReporter {
+	return &JUnitReporter{FailAt: failAt}
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// Render writes a single module report as JUnit XML.
+func (r *JUnitReporter) Render(w io.Writer, report *Report) error {
+	return r.RenderDocument(w, &Document{Modules: []Report{*report}, Compliance: report.Compliance})
+}
+
+// RenderDocument writes one testsuite per module, and one for the selected
+// compliance framework with a testcase per control.
+func (r *JUnitReporter) RenderDocument(w io.Writer, doc *Document) error {
+	suites := junitTestSuites{Name: "devopsctl"}
+	if doc.Metadata.Command != "" {
+		suites.Name = "devopsctl " + doc.Metadata.Command
+	}
+
+	var total float64
+	for _, report := range doc.Modules {
+		suite := r.suite(report)
+		if !doc.Metadata.GeneratedAt.IsZero() {
+			suite.Timestamp = doc.Metadata.GeneratedAt.UTC().Format("2006-01-02T15:04:05")
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		total += report.Duration.Seconds()
+		suites.Suites = append(suites.Suites, suite)
+	}
+	if doc.Compliance != nil {
+		suite := complianceSuite(doc.Compliance)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+	suites.Time = junitSeconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// suite builds the testsuite for one module.
+func (r *JUnitReporter) suite(report Report) junitTestSuite {
+	suite := junitTestSuite{
+		Name: report.Module,
+		Time: junitSeconds(report.Duration.Seconds()),
+	}
+	className := "devopsctl." + report.Module
+
+	switch {
+	case report.SkipReason != "":
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      report.Module,
+			ClassName: className,
+			Time:      suite.Time,
+			Skipped:   &junitMessage{Message: report.SkipReason},
+		})
+	case report.Error != "":
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      report.Module,
+			ClassName: className,
+			Time:      suite.Time,
+			Error:     &junitMessage{Message: report.Error, Type: statusOr(report.Status, "failed")},
+		})
+	}
+
+	// Group findings by check, keeping the order checks were first reported.
+	var checks []string
+	byCheck := make(map[string][]CheckResult)
+	for _, result := range report.Results {
+		if _, ok := byCheck[result.CheckName]; !ok {
+			checks = append(checks, result.CheckName)
+		}
+		byCheck[result.CheckName] = append(byCheck[result.CheckName], result)
+	}
+
+	// Every recorded check run becomes a testcase, so passed and skipped
+	// checks are counted too. Findings of checks without a run follow.
+	for _, run := range report.Checks {
+		suite.Cases = append(suite.Cases, r.runCase(className, run, byCheck[run.Check]))
+		delete(byCheck, run.Check)
+	}
+	for _, check := range checks {
+		if results, ok := byCheck[check]; ok {
+			suite.Cases = append(suite.Cases, r.testCase(className, check, results))
+		}
+	}
+
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      report.Module,
+			ClassName: className,
+			Time:      suite.Time,
+			SystemOut: "no findings",
+		})
+	}
+
+	for _, tc := range suite.Cases {
+		suite.Tests++
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Error != nil:
+			suite.Errors++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+	return suite
+}
+
+// complianceSuite builds a testsuite with a testcase per control. Failing
+// controls are failures and controls that are not covered are skipped.
+func complianceSuite(c *Compliance) junitTestSuite {
+	suite := junitTestSuite{Name: c.Framework, Time: junitSeconds(0)}
+	className := "devopsctl." + c.Framework
+	for _, ctl := range c.Controls {
+		tc := junitTestCase{
+			Name:      ctl.ID + " " + ctl.Title,
+			ClassName: className,
+			Time:      junitSeconds(0),
+		}
+		switch ctl.Status {
+		case ControlFail:
+			tc.Failure = &junitMessage{Message: ctl.Detail, Body: strings.Join(ctl.Resources, "\n")}
+			suite.Failures++
+		case ControlNotCovered:
+			tc.Skipped = &junitMessage{Message: ctl.Detail}
+			suite.Skipped++
+		default:
+			tc.SystemOut = ctl.Detail
+		}
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+	}
+	return suite
+}
+
+// testCase builds the testcase for one check. The check fails when any of its
+// findings is at or above the threshold; otherwise it passes and the findings
+// are listed in system-out.
+func (r *JUnitReporter) testCase(className, check string, results []CheckResult) junitTestCase {
+	tc := junitTestCase{Name: check, ClassName: className, Time: junitSeconds(0)}
+
+	var failing, passing []string
+	var levels []severity.Level
+	for _, result := range results {
+		line := fmt.Sprintf("[%s] %s: %s", result.Severity, result.ResourceID, result.Message)
+		if result.Recommendation != "" {
+			line += " (" + result.Recommendation + ")"
+		}
+		if severity.Level(result.Severity).Weight() >= r.FailAt.Weight() {
+			failing = append(failing, line)
+			levels = append(levels, severity.Level(result.Severity))
+		} else {
+			passing = append(passing, line)
+		}
+	}
+
+	if len(failing) > 0 {
+		tc.Failure = &junitMessage{
+			Message: fmt.Sprintf("%d finding(s) at or above %s", len(failing), r.FailAt),
+			Type:    string(severity.Highest(levels)),
+			Body:    strings.Join(failing, "\n"),
+		}
+	}
+	if len(passing) > 0 {
+		tc.SystemOut = fmt.Sprintf("%d finding(s) below %s:\n%s", len(passing), r.FailAt, strings.Join(passing, "\n"))
+	}
+	return tc
+}
+
+// runCase builds the testcase for a recorded check run.
+func (r *JUnitReporter) runCase(className string, run CheckRun, results []CheckResult) junitTestCase {
+	var tc junitTestCase
+	switch run.Status {
+	case CheckSkipped:
+		tc = junitTestCase{Name: run.Check, ClassName: className, Skipped: &junitMessage{Message: run.SkipReason}}
+	case CheckErrored:
+		// Partial findings of an errored check stay in the error body.
+		tc = r.testCase(className, run.Check, results)
+		tc.Error = &junitMessage{Message: run.Error, Type: CheckErrored}
+		if tc.Failure != nil {
+			tc.Error.Body = tc.Failure.Body
+			tc.Failure = nil
+		}
+	default:
+		tc = r.testCase(className, run.Check, results)
+	}
+	if len(run.Passed) > 0 {
+		lines := make([]string, len(run.Passed))
+		for i, p := range run.Passed {
+			lines[i] = fmt.Sprintf("%s: %s", p.ResourceID, p.Message)
+		}
+		evidence := fmt.Sprintf("%d resource(s) passed:\n%s", len(lines), strings.Join(lines, "\n"))
+		if tc.SystemOut != "" {
+			evidence = tc.SystemOut + "\n" + evidence
+		}
+		tc.SystemOut = evidence
+	}
+	tc.Time = junitSeconds(run.Duration.Seconds())
+	return tc
+}
+
+// junitSeconds formats a duration in seconds as JUnit expects.
+func junitSeconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
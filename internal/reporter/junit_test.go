@@ -0,0 +1,192 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaustuvbot/devopsctl/internal/severity"
+)
+
+func TestJUnitReporter_RenderDocument(t *testing.T) {
+	doc := &Document{
+		Metadata: Metadata{Command: "doctor", GeneratedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		Modules: []Report{
+			{
+				Module:   "aws",
+				Status:   "ok",
+				Duration: 1500 * time.Millisecond,
+				Results: []CheckResult{
+					{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "b1", Message: "public"},
+					{CheckName: "iam-key-age", Severity: "LOW", ResourceID: "alice", Message: "old key"},
+					{CheckName: "s3-public-bucket", Severity: "LOW", ResourceID: "b2", Message: "public"},
+				},
+			},
+			{Module: "docker", Status: "skipped", SkipReason: "no Dockerfile"},
+			{Module: "git", Status: "failed", Error: "boom"},
+			{Module: "terraform", Status: "ok"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJUnitReporter(severity.High).RenderDocument(&buf, doc); err != nil {
+		t.Fatalf("RenderDocument() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Error("output should start with an XML header")
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+
+	if suites.Name != "devopsctl doctor" {
+		t.Errorf("testsuites name = %q", suites.Name)
+	}
+	if suites.Tests != 5 || suites.Failures != 1 || suites.Errors != 1 || suites.Skipped != 1 {
+		t.Errorf("totals = tests %d failures %d errors %d skipped %d, want 5/1/1/1",
+			suites.Tests, suites.Failures, suites.Errors, suites.Skipped)
+	}
+	if len(suites.Suites) != 4 {
+		t.Fatalf("suites = %d, want 4", len(suites.Suites))
+	}
+
+	aws := suites.Suites[0]
+	if aws.Name != "aws" || aws.Time != "1.500" || aws.Timestamp != "2024-01-02T03:04:05" {
+		t.Errorf("aws suite = %+v", aws)
+	}
+	if len(aws.Cases) != 2 {
+		t.Fatalf("aws cases = %d, want 2 (one per check)", len(aws.Cases))
+	}
+	s3 := aws.Cases[0]
+	if s3.Name != "s3-public-bucket" || s3.ClassName != "devopsctl.aws" {
+		t.Errorf("s3 case = %+v", s3)
+	}
+	if s3.Failure == nil || s3.Failure.Type != "CRITICAL" || !strings.Contains(s3.Failure.Body, "b1") {
+		t.Errorf("s3 failure = %+v, want CRITICAL failure for b1", s3.Failure)
+	}
+	if !strings.Contains(s3.SystemOut, "b2") {
+		t.Errorf("below-threshold finding should be listed in system-out, got %q", s3.SystemOut)
+	}
+	if key := aws.Cases[1]; key.Failure != nil || key.SystemOut == "" {
+		t.Errorf("iam-key-age case = %+v, want passed with system-out", key)
+	}
+
+	if c := suites.Suites[1].Cases[0]; c.Skipped == nil || c.Skipped.Message != "no Dockerfile" {
+		t.Errorf("docker case = %+v, want skipped", c)
+	}
+	if c := suites.Suites[2].Cases[0]; c.Error == nil || c.Error.Message != "boom" {
+		t.Errorf("git case = %+v, want error", c)
+	}
+	if c := suites.Suites[3].Cases[0]; c.Failure != nil || c.Error != nil || c.Skipped != nil {
+		t.Errorf("terraform case = %+v, want passed", c)
+	}
+}
+
+func TestJUnitReporter_Threshold(t *testing.T) {
+	report := &Report{
+		Module:  "docker",
+		Results: []CheckResult{{CheckName: "dockerfile-latest-tag", Severity: "MEDIUM", ResourceID: "Dockerfile:line1"}},
+	}
+
+	tests := []struct {
+		failAt   severity.Level
+		wantFail bool
+	}{
+		{severity.Low, true},
+		{severity.Medium, true},
+		{severity.High, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.failAt), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewJUnitReporter(tt.failAt).Render(&buf, report); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			var suites junitTestSuites
+			if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+				t.Fatalf("output is not valid XML: %v", err)
+			}
+			if got := suites.Failures == 1; got != tt.wantFail {
+				t.Errorf("failures = %d, want failure %v", suites.Failures, tt.wantFail)
+			}
+		})
+	}
+}
+
+func TestJUnitReporter_CheckRuns(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Results: []CheckResult{
+			{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "b1", Message: "public"},
+		},
+		Checks: []CheckRun{
+			{Check: "s3-no-encryption", Status: CheckPassed},
+			{Check: "s3-public-bucket", Status: CheckFailed, Findings: 1},
+			{Check: "iam-mfa-disabled", Status: CheckSkipped, SkipReason: "AccessDenied on iam:ListUsers"},
+			{Check: "ebs-unencrypted", Status: CheckErrored, Error: "throttled"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJUnitReporter(severity.High).Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+
+	suite := suites.Suites[0]
+	if suite.Tests != 4 || suite.Failures != 1 || suite.Skipped != 1 || suite.Errors != 1 {
+		t.Errorf("suite = tests %d failures %d errors %d skipped %d, want 4/1/1/1",
+			suite.Tests, suite.Failures, suite.Errors, suite.Skipped)
+	}
+	names := []string{"s3-no-encryption", "s3-public-bucket", "iam-mfa-disabled", "ebs-unencrypted"}
+	for i, tc := range suite.Cases {
+		if tc.Name != names[i] {
+			t.Errorf("case %d = %s, want %s", i, tc.Name, names[i])
+		}
+	}
+	if tc := suite.Cases[2]; tc.Skipped == nil || tc.Skipped.Message != "AccessDenied on iam:ListUsers" {
+		t.Errorf("skipped case = %+v", tc)
+	}
+	if tc := suite.Cases[3]; tc.Error == nil || tc.Error.Message != "throttled" {
+		t.Errorf("errored case = %+v", tc)
+	}
+}
+
+func TestJUnitReporter_Compliance(t *testing.T) {
+	doc := &Document{
+		Modules: []Report{{Module: "aws"}},
+		Compliance: &Compliance{
+			Framework: "cis-aws-1.5",
+			Controls: []ControlResult{
+				{ID: "1.10", Title: "MFA", Status: ControlPass},
+				{ID: "2.1.5", Title: "Public access", Status: ControlFail, Detail: "1 finding(s) from s3-public-bucket"},
+				{ID: "3.1", Title: "CloudTrail", Status: ControlNotCovered, Detail: "no automated check"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJUnitReporter(severity.High).RenderDocument(&buf, doc); err != nil {
+		t.Fatalf("RenderDocument() error = %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+
+	suite := suites.Suites[len(suites.Suites)-1]
+	if suite.Name != "cis-aws-1.5" || suite.Tests != 3 || suite.Failures != 1 || suite.Skipped != 1 {
+		t.Errorf("suite = %s tests %d failures %d skipped %d, want cis-aws-1.5 3/1/1",
+			suite.Name, suite.Tests, suite.Failures, suite.Skipped)
+	}
+	if tc := suite.Cases[2]; tc.Skipped == nil || tc.Skipped.Message != "no automated check" {
+		t.Errorf("not covered case = %+v", tc)
+	}
+}
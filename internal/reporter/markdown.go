@@ -3,7 +3,9 @@ package reporter
 import (
 	"fmt"
 	"io"
+	"strings"
 	"text/tabwriter"
+	"time"
 )
 
 // MarkdownReporter renders reports in Markdown format.
@@ -19,20 +21,92 @@ func (r *MarkdownReporter) Render(w io.Writer, report *Report) error {
 	if _, err := fmt.Fprintf(w, "# %s Audit Report\n\n", titleCase(report.Module)); err != nil {
 		return err
 	}
+	if report.Profile != "" {
+		if _, err := fmt.Fprintf(w, "- **Profile**: %s\n\n", report.Profile); err != nil {
+			return err
+		}
+	}
+	return r.renderModule(w, report, "##")
+}
 
-	if len(report.Results) == 0 {
-		_, err := fmt.Fprintf(w, "No findings.\n\n")
+// RenderDocument outputs a full report with metadata, summary and one
+// section per module.
+func (r *MarkdownReporter) RenderDocument(w io.Writer, doc *Document) error {
+	if _, err := fmt.Fprintf(w, "# devopsctl %s Report\n\n", titleCase(doc.Metadata.Command)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "- **Version**: %s\n", doc.Metadata.Version); err != nil {
+		return err
+	}
+	if doc.Metadata.Profile != "" {
+		if _, err := fmt.Fprintf(w, "- **Profile**: %s\n", doc.Metadata.Profile); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "- **Generated**: %s\n\n", doc.Metadata.GeneratedAt.Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	if err := renderMarkdownSummary(w, doc.Summary); err != nil {
 		return err
 	}
 
+	for i := range doc.Modules {
+		report := &doc.Modules[i]
+		if _, err := fmt.Fprintf(w, "## %s\n\n", titleCase(report.Module)); err != nil {
+			return err
+		}
+		switch {
+		case report.SkipReason != "":
+			if _, err := fmt.Fprintf(w, "_Skipped: %s_\n\n", report.SkipReason); err != nil {
+				return err
+			}
+			continue
+		case report.Error != "":
+			if _, err := fmt.Fprintf(w, "**%s**: %s\n\n", titleCase(statusOr(report.Status, "error")), report.Error); err != nil {
+				return err
+			}
+		}
+		if err := r.renderModule(w, report, "###"); err != nil {
+			return err
+		}
+	}
+	return renderMarkdownCompliance(w, doc.Compliance, "##")
+}
+
+// renderModule writes the findings table and recommendations for one module.
+// heading is the Markdown heading prefix used for the recommendations section.
+func (r *MarkdownReporter) renderModule(w io.Writer, report *Report, heading string) error {
+	if len(report.Results) == 0 {
+		if _, err := fmt.Fprintf(w, "No findings.\n\n"); err != nil {
+			return err
+		}
+		return renderMarkdownExtras(w, report, heading)
+	}
+
 	// Create a tabwriter for alignment
+	accounts := hasAccounts(report.Results)
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintf(tw, "| %s | %s | %s | %s |\n", "Severity", "Check", "Resource", "Message")
-	_, _ = fmt.Fprintf(tw, "| --- | --- | --- | --- |\n")
+	if accounts {
+		_, _ = fmt.Fprintf(tw, "| %s | %s | %s | %s | %s |\n", "Severity", "Check", "Account", "Resource", "Message")
+		_, _ = fmt.Fprintf(tw, "| --- | --- | --- | --- | --- |\n")
+	} else {
+		_, _ = fmt.Fprintf(tw, "| %s | %s | %s | %s |\n", "Severity", "Check", "Resource", "Message")
+		_, _ = fmt.Fprintf(tw, "| --- | --- | --- | --- |\n")
+	}
 
 	for _, result := range report.Results {
+		sev := result.Severity
+		if result.OriginalSeverity != "" {
+			sev += " (was " + result.OriginalSeverity + ")"
+		}
+		if accounts {
+			_, _ = fmt.Fprintf(tw, "| %s | %s | %s | %s | %s |\n",
+				sev, result.CheckName, dashIfEmpty(result.AccountName()), result.ResourceID, result.Message)
+			continue
+		}
 		_, _ = fmt.Fprintf(tw, "| %s | %s | %s | %s |\n",
-			result.Severity,
+			sev,
 			result.CheckName,
 			result.ResourceID,
 			result.Message,
@@ -45,7 +119,7 @@ func (r *MarkdownReporter) Render(w io.Writer, report *Report) error {
 	}
 
 	// Add recommendations section
-	if _, err := fmt.Fprintf(w, "## Recommendations\n\n"); err != nil {
+	if _, err := fmt.Fprintf(w, "%s Recommendations\n\n", heading); err != nil {
 		return err
 	}
 	for _, result := range report.Results {
@@ -56,7 +130,168 @@ func (r *MarkdownReporter) Render(w io.Writer, report *Report) error {
 		}
 	}
 
-	_, err := fmt.Fprintf(w, "\n")
+	if _, err := fmt.Fprintf(w, "\n"); err != nil {
+		return err
+	}
+	return renderMarkdownExtras(w, report, heading)
+}
+
+// renderMarkdownExtras lists a module's accounts, suppressed and resolved
+// findings, the resources that passed and its check coverage.
+func renderMarkdownExtras(w io.Writer, report *Report, heading string) error {
+	if err := renderMarkdownAccounts(w, report.Accounts, heading); err != nil {
+		return err
+	}
+	if err := renderMarkdownSuppressed(w, report.Suppressed, heading); err != nil {
+		return err
+	}
+	if err := renderMarkdownResolved(w, report.Resolved, heading); err != nil {
+		return err
+	}
+	if err := renderMarkdownPassed(w, report.Checks, heading); err != nil {
+		return err
+	}
+	if err := renderMarkdownCoverage(w, report, heading); err != nil {
+		return err
+	}
+	return renderMarkdownCompliance(w, report.Compliance, heading)
+}
+
+// renderMarkdownAccounts writes the findings of each account of a
+// multi-account audit.
+func renderMarkdownAccounts(w io.Writer, accounts []AccountSummary, heading string) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s Accounts\n\n", heading)
+	b.WriteString("| Account | ID | Findings | Critical | High | Medium | Low | Error |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, a := range accounts {
+		fmt.Fprintf(&b, "| %s | %s | %d | %d | %d | %d | %d | %s |\n",
+			a.Name(), a.ID, a.Findings, a.Critical, a.High, a.Medium, a.Low, dashIfEmpty(a.Error))
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderMarkdownCompliance writes the status of each framework control.
+func renderMarkdownCompliance(w io.Writer, c *Compliance, heading string) error {
+	if c == nil {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s Compliance: %s\n\n", heading, c.Name)
+	fmt.Fprintf(&b, "%d pass, %d fail, %d not covered\n\n", c.Passed, c.Failed, c.NotCovered)
+	b.WriteString("| Control | Status | Title | Detail |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for _, ctl := range c.Controls {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", ctl.ID, ctl.Status, ctl.Title, ctl.Detail)
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderMarkdownPassed lists the resources that passed each check.
+func renderMarkdownPassed(w io.Writer, runs []CheckRun, heading string) error {
+	if passedCount(runs) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s Passed\n\n", heading)
+	b.WriteString("| Check | Resource | Detail |\n")
+	b.WriteString("| --- | --- | --- |\n")
+	for _, run := range runs {
+		for _, p := range run.Passed {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", run.Check, p.ResourceID, p.Message)
+		}
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderMarkdownResolved lists baseline findings that no longer occur.
+func renderMarkdownResolved(w io.Writer, resolved []CheckResult, heading string) error {
+	if len(resolved) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s Resolved Since Baseline\n\n", heading)
+	for _, r := range resolved {
+		fmt.Fprintf(&b, "- %s `%s` on `%s`\n", r.Severity, r.CheckName, r.ResourceID)
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderMarkdownCoverage writes how many checks ran and lists those that
+// did not or ran on only some resources.
+func renderMarkdownCoverage(w io.Writer, report *Report, heading string) error {
+	if len(report.Checks) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Coverage**: %s\n\n", coverageLine(report.Module, report.Checks))
+	if runs := notCovered(report.Checks); len(runs) > 0 {
+		fmt.Fprintf(&b, "%s Checks Not Executed\n\n", heading)
+		b.WriteString("| Check | Status | Reason |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, run := range runs {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", run.Check, run.Status, reasonOf(run))
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderMarkdownSuppressed lists suppressed findings with their justification.
+func renderMarkdownSuppressed(w io.Writer, suppressed []SuppressedResult, heading string) error {
+	if len(suppressed) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s Suppressed\n\n", heading)
+	b.WriteString("| Severity | Check | Resource | Reason | Owner | Expires |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, s := range suppressed {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+			s.Severity, s.CheckName, s.ResourceID, s.Reason, dashIfEmpty(s.Owner), dashIfEmpty(s.Expires))
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderMarkdownSummary writes the summary section with severity counts and score.
+func renderMarkdownSummary(w io.Writer, s Summary) error {
+	var b strings.Builder
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Severity | Count |\n| --- | --- |\n")
+	fmt.Fprintf(&b, "| CRITICAL | %d |\n| HIGH | %d |\n| MEDIUM | %d |\n| LOW | %d |\n", s.Critical, s.High, s.Medium, s.Low)
+	fmt.Fprintf(&b, "| **Total** | %d |\n\n", s.TotalFindings)
+	fmt.Fprintf(&b, "**Score**: %d\n\n", s.Score)
+	if s.ResolvedFindings > 0 {
+		fmt.Fprintf(&b, "**Resolved since baseline**: %d\n\n", s.ResolvedFindings)
+	}
+	if c := s.Coverage; c != nil {
+		fmt.Fprintf(&b, "**Checks**: %d of %d executed (%d skipped, %d errored)\n\n", c.Executed, c.Total, c.Skipped, c.Errored)
+	}
+	fmt.Fprintf(&b, "**Modules**: %d failed, %d timed out, %d skipped\n\n", s.ModulesFailed, s.ModulesTimedOut, s.ModulesSkipped)
+
+	if len(s.ModuleErrors) > 0 {
+		b.WriteString("### Module Errors\n\n")
+		for _, module := range sortedKeys(s.ModuleErrors) {
+			fmt.Fprintf(&b, "- **%s**: %s\n", module, s.ModuleErrors[module])
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
 	return err
 }
 
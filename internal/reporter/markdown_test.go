@@ -179,3 +179,55 @@ func TestTitleCase(t *testing.T) {
 		}
 	}
 }
+
+func TestMarkdownReporter_RenderDocument(t *testing.T) {
+	doc := &Document{
+		Metadata: Metadata{Tool: "devopsctl", Version: "dev", Command: "doctor"},
+		Modules: []Report{
+			{Module: "aws", Status: "ok", Results: []CheckResult{
+				{CheckName: "iam-mfa-disabled", Severity: "HIGH", ResourceID: "alice", Recommendation: "Enable MFA"},
+			}},
+			{Module: "docker", Status: "skipped", SkipReason: "no Dockerfile"},
+		},
+		Summary: Summary{TotalFindings: 1, High: 1, Score: 3, ModulesSkipped: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownReporter().RenderDocument(&buf, doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	if strings.Count(out, "\n# ") != 0 || !strings.HasPrefix(out, "# devopsctl Doctor Report") {
+		t.Errorf("expected a single top-level heading, got:\n%s", out)
+	}
+	for _, want := range []string{"## Summary", "| HIGH | 1 |", "**Score**: 3", "## Aws", "### Recommendations", "_Skipped: no Dockerfile_"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownReporter_Suppressed(t *testing.T) {
+	rep := NewMarkdownReporter()
+	var buf bytes.Buffer
+	report := &Report{
+		Module:  "aws",
+		Results: []CheckResult{{CheckName: "s3-public-bucket", Severity: "CRITICAL", ResourceID: "b1"}},
+		Suppressed: []SuppressedResult{
+			{
+				CheckResult: CheckResult{CheckName: "s3-versioning-disabled", Severity: "MEDIUM", ResourceID: "app-logs"},
+				Reason:      "lifecycle rules",
+				Expires:     "2025-06-30",
+			},
+		},
+	}
+	if err := rep.Render(&buf, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	want := "| MEDIUM | s3-versioning-disabled | app-logs | lifecycle rules | - | 2025-06-30 |"
+	if !strings.Contains(out, "## Suppressed") || !strings.Contains(out, want) {
+		t.Errorf("expected suppressed section with %q, got:\n%s", want, out)
+	}
+}
@@ -1,23 +1,83 @@
 package reporter
 
-import "io"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"time"
+)
 
 // CheckResult represents the output of a single check.
+// OriginalSeverity is set when a config override changed Severity. Account
+// and AccountAlias identify the AWS account of a finding in a multi-account
+// audit.
 type CheckResult struct {
-	CheckName      string `json:"check_name"`
-	Severity       string `json:"severity"`
-	ResourceID     string `json:"resource_id"`
-	Message        string `json:"message"`
-	Recommendation string `json:"recommendation"`
+	CheckName        string `json:"check_name"`
+	Severity         string `json:"severity"`
+	OriginalSeverity string `json:"original_severity,omitempty"`
+	Account          string `json:"account,omitempty"`
+	AccountAlias     string `json:"account_alias,omitempty"`
+	ResourceID       string `json:"resource_id"`
+	Message          string `json:"message"`
+	Recommendation   string `json:"recommendation"`
+}
+
+// Fingerprint returns a stable identifier for a finding, derived from the
+// module, check, account and resource. It does not change with severity or
+// message wording, so a finding can be tracked across runs.
+func (r CheckResult) Fingerprint(module string) string {
+	key := module + "|" + r.CheckName + "|" + r.ResourceID
+	if r.Account != "" {
+		key = module + "|" + r.Account + "|" + r.CheckName + "|" + r.ResourceID
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// AccountName returns the alias of the finding's account, its ID when it has
+// no alias, or "" for a finding that is not tagged with an account.
+func (r CheckResult) AccountName() string {
+	return accountName(r.Account, r.AccountAlias)
+}
+
+// SuppressedResult is a finding hidden by a config suppression, with the
+// suppression's justification.
+type SuppressedResult struct {
+	CheckResult
+	Reason  string `json:"reason"`
+	Owner   string `json:"owner,omitempty"`
+	Expires string `json:"expires,omitempty"`
 }
 
 // Report holds a collection of check results for a module.
+// Status, Error, SkipReason and Duration are set when the report is part
+// of a multi-module Document. Suppressed is only set when suppressed
+// findings were requested, and Resolved lists baseline findings that no
+// longer occur when results were compared against a baseline. Checks records
+// the status of each check the module ran, when the module reports it, and
+// Compliance groups the results by framework control when one was selected.
+// Profile names the config profile of a standalone report; documents record
+// it in their Metadata instead. Accounts summarises each account of a
+// multi-account AWS audit.
 type Report struct {
-	Module  string        `json:"module"`
-	Results []CheckResult `json:"results"`
+	Module     string             `json:"module"`
+	Profile    string             `json:"profile,omitempty"`
+	Status     string             `json:"status,omitempty"`
+	Results    []CheckResult      `json:"results"`
+	Suppressed []SuppressedResult `json:"suppressed,omitempty"`
+	Resolved   []CheckResult      `json:"resolved,omitempty"`
+	Checks     []CheckRun         `json:"checks,omitempty"`
+	Accounts   []AccountSummary   `json:"accounts,omitempty"`
+	Compliance *Compliance        `json:"compliance,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	SkipReason string             `json:"skip_reason,omitempty"`
+	Duration   time.Duration      `json:"duration_ns,omitempty"`
 }
 
 // Reporter defines the interface for output formatting.
 type Reporter interface {
+	// Render writes a single module report.
 	Render(w io.Writer, report *Report) error
+	// RenderDocument writes a multi-module document, including its summary.
+	RenderDocument(w io.Writer, doc *Document) error
 }
@@ -0,0 +1,356 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kaustuvbot/devopsctl/internal/catalog"
+	"github.com/kaustuvbot/devopsctl/internal/severity"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolInfoURI  = "https://github.com/kaustuvbot/devopsctl"
+)
+
+// SARIFReporter renders results as a SARIF 2.1.0 log for code-scanning tools.
+type SARIFReporter struct {
+	// Version is reported as the tool driver version.
+	Version string
+}
+
+// NewSARIFReporter creates a new SARIF reporter.
+func NewSARIFReporter(version string) *SARIFReporter {
+	return &SARIFReporter{Version: version}
+}
+
+// SARIF 2.1.0 object model, limited to the properties devopsctl emits.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations,omitempty"`
+	Results     []sarifResult     `json:"results"`
+	Properties  map[string]string `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      *sarifMessage      `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage      `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifRuleProps     `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProps struct {
+	Tags             []string `json:"tags"`
+	SecuritySeverity string   `json:"security-severity"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Kind                string             `json:"kind,omitempty"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	Properties          map[string]string  `json:"properties"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+// Render writes a single module report as a SARIF log.
+func (r *SARIFReporter) Render(w io.Writer, report *Report) error {
+	return r.RenderDocument(w, &Document{Metadata: Metadata{Profile: report.Profile}, Modules: []Report{*report}})
+}
+
+// RenderDocument writes all modules of the document as one SARIF run.
+func (r *SARIFReporter) RenderDocument(w io.Writer, doc *Document) error {
+	version := r.Version
+	if doc.Metadata.Version != "" {
+		version = doc.Metadata.Version
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "devopsctl",
+			Version:        version,
+			InformationURI: toolInfoURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	if doc.Metadata.Profile != "" {
+		run.Properties = map[string]string{"profile": doc.Metadata.Profile}
+	}
+
+	ruleIndex := make(map[string]int)
+	invocation := sarifInvocation{ExecutionSuccessful: true}
+
+	for _, report := range doc.Modules {
+		switch {
+		case report.SkipReason != "":
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+				Level:   "note",
+				Message: sarifMessage{Text: fmt.Sprintf("%s module skipped: %s", report.Module, report.SkipReason)},
+			})
+		case report.Error != "":
+			invocation.ExecutionSuccessful = false
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+				Level:   "error",
+				Message: sarifMessage{Text: fmt.Sprintf("%s module %s: %s", report.Module, statusOr(report.Status, "failed"), report.Error)},
+			})
+		}
+		for _, checkRun := range notExecuted(report.Checks) {
+			n := sarifNotification{
+				Level:   "note",
+				Message: sarifMessage{Text: fmt.Sprintf("%s check %s skipped: %s", report.Module, checkRun.Check, checkRun.SkipReason)},
+			}
+			if checkRun.Status == CheckErrored {
+				invocation.ExecutionSuccessful = false
+				n.Level = "error"
+				n.Message.Text = fmt.Sprintf("%s check %s errored: %s", report.Module, checkRun.Check, checkRun.Error)
+			}
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, n)
+		}
+
+		addResult := func(result CheckResult) *sarifResult {
+			idx, ok := ruleIndex[result.CheckName]
+			if !ok {
+				idx = len(run.Tool.Driver.Rules)
+				ruleIndex[result.CheckName] = idx
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRuleFor(report.Module, result))
+			}
+			run.Results = append(run.Results, newSARIFResult(report.Module, idx, result))
+			return &run.Results[len(run.Results)-1]
+		}
+		for _, result := range report.Results {
+			addResult(result)
+		}
+		// Suppressed findings are kept in the log, marked as suppressed,
+		// so viewers can show them with their justification.
+		for _, s := range report.Suppressed {
+			res := addResult(s.CheckResult)
+			res.Suppressions = []sarifSuppression{{Kind: "external", Justification: s.Reason}}
+		}
+		// Pass records become results of kind "pass", the evidence that a
+		// resource was evaluated and complied.
+		for _, checkRun := range report.Checks {
+			for _, p := range checkRun.Passed {
+				res := addResult(CheckResult{CheckName: checkRun.Check, ResourceID: p.ResourceID, Message: p.Message})
+				res.Kind = "pass"
+				res.Level = "none"
+				delete(res.Properties, "severity")
+			}
+		}
+	}
+
+	if len(invocation.ToolExecutionNotifications) > 0 || !invocation.ExecutionSuccessful {
+		run.Invocations = []sarifInvocation{invocation}
+	}
+
+	log := sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// newSARIFResult converts a finding into a SARIF result for the rule at idx.
+func newSARIFResult(module string, idx int, result CheckResult) sarifResult {
+	props := map[string]string{
+		"module":   module,
+		"resource": result.ResourceID,
+		"severity": result.Severity,
+	}
+	if result.OriginalSeverity != "" {
+		props["original_severity"] = result.OriginalSeverity
+	}
+	if result.Account != "" {
+		props["account"] = result.Account
+		if result.AccountAlias != "" {
+			props["account_alias"] = result.AccountAlias
+		}
+	}
+	return sarifResult{
+		RuleID:    result.CheckName,
+		RuleIndex: idx,
+		Level:     sarifLevel(result.Severity),
+		Message:   sarifMessage{Text: result.Message},
+		Locations: []sarifLocation{sarifLocationFor(module, result)},
+		PartialFingerprints: map[string]string{
+			"devopsctl/v1": result.Fingerprint(module),
+		},
+		Properties: props,
+	}
+}
+
+// sarifRuleFor builds the rule of a check from its catalog entry, so rules
+// do not depend on which result is seen first or on severity overrides. A
+// check missing from the catalog falls back to the result.
+func sarifRuleFor(module string, result CheckResult) sarifRule {
+	meta, ok := catalog.Lookup(result.CheckName)
+	if !ok {
+		meta = catalog.Check{ID: result.CheckName, Title: result.CheckName, Severity: severity.Level(result.Severity)}
+		if result.Recommendation != "" {
+			meta.Remediation = []string{result.Recommendation}
+		}
+	}
+	rule := sarifRule{
+		ID:                   meta.ID,
+		Name:                 meta.Title,
+		ShortDescription:     sarifMessage{Text: meta.Title},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(string(meta.Severity))},
+		Properties: sarifRuleProps{
+			Tags:             []string{"devopsctl", module, "security"},
+			SecuritySeverity: sarifSecuritySeverity(string(meta.Severity)),
+		},
+	}
+	if meta.Description != "" {
+		rule.FullDescription = &sarifMessage{Text: meta.Description}
+	}
+	if len(meta.Remediation) > 0 {
+		rule.Help = &sarifMessage{Text: strings.Join(meta.Remediation, " ")}
+	}
+	return rule
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s string) string {
+	switch severity.Level(s) {
+	case severity.Critical, severity.High:
+		return "error"
+	case severity.Medium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifSecuritySeverity maps a severity to the numeric score code-scanning
+// dashboards use to bucket alerts.
+func sarifSecuritySeverity(s string) string {
+	switch severity.Level(s) {
+	case severity.Critical:
+		return "9.5"
+	case severity.High:
+		return "8.0"
+	case severity.Medium:
+		return "5.5"
+	default:
+		return "3.0"
+	}
+}
+
+// lineSuffix matches resource IDs of the form "path:lineN".
+var lineSuffix = regexp.MustCompile(`^(.+):line(\d+)$`)
+
+// sarifLocationFor returns a physical location when the resource ID names a
+// file, and a logical location otherwise (cloud resources, branches).
+func sarifLocationFor(module string, result CheckResult) sarifLocation {
+	if path, line, ok := fileLocation(module, result); ok {
+		loc := &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifURI(path)}}
+		if line > 0 {
+			loc.Region = &sarifRegion{StartLine: line}
+		}
+		return sarifLocation{PhysicalLocation: loc}
+	}
+	return sarifLocation{LogicalLocations: []sarifLogicalLocation{{Name: result.ResourceID, Kind: "resource"}}}
+}
+
+// fileLocation extracts a file path and optional line from a result's
+// resource ID for the modules whose resources are files.
+func fileLocation(module string, result CheckResult) (string, int, bool) {
+	if m := lineSuffix.FindStringSubmatch(result.ResourceID); m != nil {
+		line, err := strconv.Atoi(m[2])
+		if err == nil {
+			return m[1], line, true
+		}
+	}
+	switch {
+	case module == "docker" && strings.HasPrefix(result.CheckName, "dockerfile-"):
+		return result.ResourceID, 0, true
+	case module == "terraform" && strings.HasSuffix(result.ResourceID, ".tf"):
+		return result.ResourceID, 0, true
+	case module == "git" && result.CheckName == "git-large-file":
+		return result.ResourceID, 0, true
+	}
+	return "", 0, false
+}
+
+// sarifURI converts a file path to a SARIF artifact URI.
+func sarifURI(path string) string {
+	path = filepath.ToSlash(filepath.Clean(path))
+	if strings.HasPrefix(path, "/") {
+		return "file://" + path
+	}
+	return path
+}
@@ -0,0 +1,219 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSARIFReporter_RenderDocument(t *testing.T) {
+	doc := &Document{
+		Metadata: Metadata{Tool: "devopsctl", Version: "1.2.3", Command: "doctor"},
+		Modules: []Report{
+			{
+				Module: "docker",
+				Results: []CheckResult{
+					{CheckName: "dockerfile-latest-tag", Severity: "MEDIUM", ResourceID: "Dockerfile:line3", Message: "uses latest", Recommendation: "Pin the tag"},
+					{CheckName: "dockerfile-runs-as-root", Severity: "HIGH", ResourceID: "Dockerfile", Message: "runs as root"},
+					{CheckName: "dockerfile-latest-tag", Severity: "MEDIUM", ResourceID: "Dockerfile:line9", Message: "uses latest"},
+				},
+			},
+			{
+				Module: "terraform",
+				Results: []CheckResult{
+					{CheckName: "hardcoded-credentials", Severity: "CRITICAL", ResourceID: "infra/main.tf", Message: "secret"},
+				},
+			},
+			{
+				Module: "aws",
+				Results: []CheckResult{
+					{CheckName: "s3-public-bucket", Severity: "LOW", ResourceID: "my-bucket", Message: "public"},
+				},
+			},
+			{Module: "git", Status: "skipped", SkipReason: "not a git repository"},
+			{Module: "extra", Status: "failed", Error: "boom"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("dev").RenderDocument(&buf, doc); err != nil {
+		t.Fatalf("RenderDocument() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log header: version=%q runs=%d", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	if run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver version = %q, want 1.2.3", run.Tool.Driver.Version)
+	}
+	if len(run.Tool.Driver.Rules) != 4 {
+		t.Errorf("rules = %d, want 4 (one per distinct check)", len(run.Tool.Driver.Rules))
+	}
+	// Rules come from the catalog, not from the first result of a check.
+	if rule := run.Tool.Driver.Rules[0]; rule.Name != "Base image uses a mutable tag" || rule.Help == nil ||
+		!strings.HasPrefix(rule.Help.Text, "Pin the base image") || rule.FullDescription == nil {
+		t.Errorf("rule = %+v, want the catalog entry of dockerfile-latest-tag", rule)
+	}
+	// s3-public-bucket's severity was overridden to LOW: the rule keeps the
+	// catalog default, the result the override.
+	if rule := run.Tool.Driver.Rules[3]; rule.DefaultConfiguration.Level != "error" || rule.Properties.SecuritySeverity != "9.5" {
+		t.Errorf("overridden rule = %+v, want the catalog's CRITICAL", rule)
+	}
+	if len(run.Results) != 5 {
+		t.Fatalf("results = %d, want 5", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.Level != "warning" {
+		t.Errorf("MEDIUM level = %q, want warning", first.Level)
+	}
+	loc := first.Locations[0].PhysicalLocation
+	if loc == nil || loc.ArtifactLocation.URI != "Dockerfile" || loc.Region == nil || loc.Region.StartLine != 3 {
+		t.Errorf("dockerfile location = %+v, want Dockerfile line 3", loc)
+	}
+	if run.Results[2].RuleIndex != 0 {
+		t.Errorf("repeated check ruleIndex = %d, want 0", run.Results[2].RuleIndex)
+	}
+	if first.PartialFingerprints["devopsctl/v1"] == run.Results[2].PartialFingerprints["devopsctl/v1"] {
+		t.Error("different resources should have different fingerprints")
+	}
+
+	if loc := run.Results[1].Locations[0].PhysicalLocation; loc == nil || loc.Region != nil {
+		t.Errorf("root-user location = %+v, want file without region", loc)
+	}
+	if tf := run.Results[3]; tf.Level != "error" || tf.Locations[0].PhysicalLocation.ArtifactLocation.URI != "infra/main.tf" {
+		t.Errorf("terraform result = %+v, want error at infra/main.tf", tf)
+	}
+	aws := run.Results[4]
+	if aws.Level != "note" || aws.Locations[0].PhysicalLocation != nil || aws.Locations[0].LogicalLocations[0].Name != "my-bucket" {
+		t.Errorf("aws result = %+v, want note with logical location", aws)
+	}
+
+	if len(run.Invocations) != 1 {
+		t.Fatalf("invocations = %d, want 1", len(run.Invocations))
+	}
+	inv := run.Invocations[0]
+	if inv.ExecutionSuccessful {
+		t.Error("executionSuccessful should be false when a module failed")
+	}
+	if len(inv.ToolExecutionNotifications) != 2 {
+		t.Errorf("notifications = %d, want 2", len(inv.ToolExecutionNotifications))
+	}
+}
+
+func TestSARIFReporter_Suppressed(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Suppressed: []SuppressedResult{{
+			CheckResult: CheckResult{CheckName: "s3-versioning-disabled", Severity: "MEDIUM", ResourceID: "app-logs"},
+			Reason:      "lifecycle rules",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("dev").Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 || len(results[0].Suppressions) != 1 {
+		t.Fatalf("results = %+v, want one suppressed result", results)
+	}
+	if s := results[0].Suppressions[0]; s.Kind != "external" || s.Justification != "lifecycle rules" {
+		t.Errorf("suppression = %+v", s)
+	}
+}
+
+func TestSARIFReporter_RenderEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("dev").Render(&buf, &Report{Module: "aws", Results: []CheckResult{}}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Version != "dev" {
+		t.Errorf("driver version = %q, want dev", run.Tool.Driver.Version)
+	}
+	if run.Results == nil || len(run.Results) != 0 {
+		t.Errorf("results = %v, want empty array", run.Results)
+	}
+	if run.Invocations != nil {
+		t.Errorf("invocations = %v, want none", run.Invocations)
+	}
+}
+
+func TestSARIFReporter_CheckNotifications(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Checks: []CheckRun{
+			{Check: "s3-public-bucket", Status: CheckPassed},
+			{Check: "iam-mfa-disabled", Status: CheckSkipped, SkipReason: "AccessDenied on iam:ListUsers"},
+			{Check: "ebs-unencrypted", Status: CheckErrored, Error: "throttled"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("dev").Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	inv := log.Runs[0].Invocations
+	if len(inv) != 1 || inv[0].ExecutionSuccessful {
+		t.Fatalf("invocations = %+v, want one unsuccessful invocation", inv)
+	}
+	notes := inv[0].ToolExecutionNotifications
+	if len(notes) != 2 {
+		t.Fatalf("notifications = %+v, want 2", notes)
+	}
+	if notes[0].Level != "note" || notes[0].Message.Text != "aws check iam-mfa-disabled skipped: AccessDenied on iam:ListUsers" {
+		t.Errorf("notes[0] = %+v", notes[0])
+	}
+	if notes[1].Level != "error" || notes[1].Message.Text != "aws check ebs-unencrypted errored: throttled" {
+		t.Errorf("notes[1] = %+v", notes[1])
+	}
+}
+
+func TestSARIFReporter_PassRecords(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Checks: []CheckRun{{
+			Check:     "s3-public-bucket",
+			Status:    CheckPassed,
+			Evaluated: 1,
+			Passed:    []Evidence{{ResourceID: "app-logs", Message: "not public"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("dev").Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("results = %+v, want one pass result", results)
+	}
+	if r := results[0]; r.Kind != "pass" || r.Level != "none" || r.RuleID != "s3-public-bucket" {
+		t.Errorf("result = %+v", r)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/kaustuvbot/devopsctl/internal/severity"
 )
@@ -40,27 +42,260 @@ func colorize(s string) string {
 
 // Render writes a formatted table of check results to w.
 func (r *TableReporter) Render(w io.Writer, report *Report) error {
-	// Check if terminal supports colors
-	isTerminal := isTerminal(w)
+	return r.renderModule(w, report, isTerminal(w))
+}
+
+// RenderDocument writes every module table followed by a summary block.
+func (r *TableReporter) RenderDocument(w io.Writer, doc *Document) error {
+	color := isTerminal(w)
 
+	profile := ""
+	if doc.Metadata.Profile != "" {
+		profile = ", profile " + doc.Metadata.Profile
+	}
+	if _, err := fmt.Fprintf(w, "devopsctl %s report (version %s%s, generated %s)\n\n",
+		doc.Metadata.Command, doc.Metadata.Version, profile, doc.Metadata.GeneratedAt.Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	for i := range doc.Modules {
+		report := &doc.Modules[i]
+		if err := r.renderModule(w, report, color); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	if err := renderTableSummary(w, doc.Summary); err != nil {
+		return err
+	}
+	return renderTableCompliance(w, doc.Compliance)
+}
+
+// renderModule writes one module section, including its run status when
+// the module did not complete normally.
+func (r *TableReporter) renderModule(w io.Writer, report *Report, color bool) error {
 	if _, err := fmt.Fprintf(w, "=== %s Audit Results ===\n\n", report.Module); err != nil {
 		return err
 	}
-	if len(report.Results) == 0 {
-		_, err := fmt.Fprintln(w, "No issues found.")
+	if report.Profile != "" {
+		if _, err := fmt.Fprintf(w, "Profile: %s\n\n", report.Profile); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case report.SkipReason != "":
+		_, err := fmt.Fprintf(w, "[SKIPPED] %s\n", report.SkipReason)
 		return err
+	case report.Error != "":
+		if _, err := fmt.Fprintf(w, "[%s] %s\n", strings.ToUpper(statusOr(report.Status, "error")), report.Error); err != nil {
+			return err
+		}
 	}
+
+	if len(report.Results) == 0 {
+		if _, err := fmt.Fprintln(w, "No issues found."); err != nil {
+			return err
+		}
+		return renderTableExtras(w, report)
+	}
+	// Findings of a multi-account audit show the account of each resource.
+	accounts := hasAccounts(report.Results)
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "SEVERITY\tCHECK NAME\tRESOURCE\tMESSAGE")
-	_, _ = fmt.Fprintln(tw, "--------\t----------\t--------\t-------")
+	if accounts {
+		_, _ = fmt.Fprintln(tw, "SEVERITY\tCHECK NAME\tACCOUNT\tRESOURCE\tMESSAGE")
+		_, _ = fmt.Fprintln(tw, "--------\t----------\t-------\t--------\t-------")
+	} else {
+		_, _ = fmt.Fprintln(tw, "SEVERITY\tCHECK NAME\tRESOURCE\tMESSAGE")
+		_, _ = fmt.Fprintln(tw, "--------\t----------\t--------\t-------")
+	}
 	for _, result := range report.Results {
 		sev := result.Severity
-		if isTerminal {
+		if color {
 			sev = colorize(sev)
 		}
+		if result.OriginalSeverity != "" {
+			sev += " (was " + result.OriginalSeverity + ")"
+		}
+		if accounts {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+				sev, result.CheckName, dashIfEmpty(result.AccountName()), result.ResourceID, result.Message)
+			continue
+		}
 		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
 			sev, result.CheckName, result.ResourceID, result.Message)
 	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	return renderTableExtras(w, report)
+}
+
+// renderTableExtras lists a module's accounts, suppressed and resolved
+// findings, the resources that passed and its check coverage.
+func renderTableExtras(w io.Writer, report *Report) error {
+	if err := renderTableAccounts(w, report.Accounts); err != nil {
+		return err
+	}
+	if err := renderTableSuppressed(w, report.Suppressed); err != nil {
+		return err
+	}
+	if err := renderTableResolved(w, report.Resolved); err != nil {
+		return err
+	}
+	if err := renderTablePassed(w, report.Checks); err != nil {
+		return err
+	}
+	if err := renderTableCoverage(w, report); err != nil {
+		return err
+	}
+	return renderTableCompliance(w, report.Compliance)
+}
+
+// renderTableAccounts writes the findings of each account of a
+// multi-account audit and the accounts that could not be audited.
+func renderTableAccounts(w io.Writer, accounts []AccountSummary) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "\nAccounts (%d):\n", len(accounts)); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ACCOUNT\tID\tFINDINGS\tCRITICAL\tHIGH\tMEDIUM\tLOW\tERROR")
+	_, _ = fmt.Fprintln(tw, "-------\t--\t--------\t--------\t----\t------\t---\t-----")
+	for _, a := range accounts {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			a.Name(), a.ID, a.Findings, a.Critical, a.High, a.Medium, a.Low, dashIfEmpty(a.Error))
+	}
+	return tw.Flush()
+}
+
+// renderTableCompliance writes the status of each framework control.
+func renderTableCompliance(w io.Writer, c *Compliance) error {
+	if c == nil {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "\nCompliance: %s\n", complianceLine(c)); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CONTROL\tSTATUS\tTITLE\tDETAIL")
+	_, _ = fmt.Fprintln(tw, "-------\t------\t-----\t------")
+	for _, ctl := range c.Controls {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", ctl.ID, ctl.Status, ctl.Title, ctl.Detail)
+	}
+	return tw.Flush()
+}
+
+// renderTablePassed lists the resources that passed each check.
+func renderTablePassed(w io.Writer, runs []CheckRun) error {
+	n := passedCount(runs)
+	if n == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "\nPassed (%d):\n", n); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHECK NAME\tRESOURCE\tDETAIL")
+	_, _ = fmt.Fprintln(tw, "----------\t--------\t------")
+	for _, run := range runs {
+		for _, p := range run.Passed {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", run.Check, p.ResourceID, p.Message)
+		}
+	}
+	return tw.Flush()
+}
+
+// renderTableCoverage writes how many checks ran and lists those that did not
+// or ran on only some resources.
+func renderTableCoverage(w io.Writer, report *Report) error {
+	if len(report.Checks) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "\nCoverage: %s\n", coverageLine(report.Module, report.Checks)); err != nil {
+		return err
+	}
+	runs := notCovered(report.Checks)
+	if len(runs) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHECK NAME\tSTATUS\tREASON")
+	_, _ = fmt.Fprintln(tw, "----------\t------\t------")
+	for _, run := range runs {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", run.Check, run.Status, reasonOf(run))
+	}
+	return tw.Flush()
+}
+
+// renderTableResolved lists baseline findings that no longer occur.
+func renderTableResolved(w io.Writer, resolved []CheckResult) error {
+	if len(resolved) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "\nResolved since baseline (%d):\n", len(resolved)); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SEVERITY\tCHECK NAME\tRESOURCE")
+	_, _ = fmt.Fprintln(tw, "--------\t----------\t--------")
+	for _, r := range resolved {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Severity, r.CheckName, r.ResourceID)
+	}
+	return tw.Flush()
+}
+
+// renderTableSuppressed lists suppressed findings with their justification.
+func renderTableSuppressed(w io.Writer, suppressed []SuppressedResult) error {
+	if len(suppressed) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "\nSuppressed (%d):\n", len(suppressed)); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SEVERITY\tCHECK NAME\tRESOURCE\tREASON\tOWNER\tEXPIRES")
+	_, _ = fmt.Fprintln(tw, "--------\t----------\t--------\t------\t-----\t-------")
+	for _, s := range suppressed {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Severity, s.CheckName, s.ResourceID, s.Reason, dashIfEmpty(s.Owner), dashIfEmpty(s.Expires))
+	}
+	return tw.Flush()
+}
+
+// dashIfEmpty returns "-" for empty strings so table columns stay aligned.
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// renderTableSummary writes severity counts, score and module failures.
+func renderTableSummary(w io.Writer, s Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "=== Summary ===")
+	_, _ = fmt.Fprintln(tw)
+	_, _ = fmt.Fprintf(tw, "Findings:\t%d (CRITICAL %d, HIGH %d, MEDIUM %d, LOW %d)\n",
+		s.TotalFindings, s.Critical, s.High, s.Medium, s.Low)
+	_, _ = fmt.Fprintf(tw, "Score:\t%d\n", s.Score)
+	if s.ResolvedFindings > 0 {
+		_, _ = fmt.Fprintf(tw, "Resolved:\t%d since baseline\n", s.ResolvedFindings)
+	}
+	if c := s.Coverage; c != nil {
+		_, _ = fmt.Fprintf(tw, "Checks:\t%d of %d executed (%d skipped, %d errored)\n",
+			c.Executed, c.Total, c.Skipped, c.Errored)
+	}
+	_, _ = fmt.Fprintf(tw, "Modules:\t%d failed, %d timed out, %d skipped\n",
+		s.ModulesFailed, s.ModulesTimedOut, s.ModulesSkipped)
+	for _, module := range sortedKeys(s.ModuleErrors) {
+		_, _ = fmt.Fprintf(tw, "  %s:\t%s\n", module, s.ModuleErrors[module])
+	}
 	return tw.Flush()
 }
 
@@ -56,3 +56,194 @@ func TestTableReporter_HeaderPresent(t *testing.T) {
 		t.Errorf("expected SEVERITY column header, got: %s", out)
 	}
 }
+
+func TestTableReporter_RenderDocument(t *testing.T) {
+	rep := NewTableReporter()
+	var buf bytes.Buffer
+	doc := &Document{
+		Metadata: Metadata{Tool: "devopsctl", Version: "dev", Command: "doctor"},
+		Modules: []Report{
+			{Module: "aws", Status: "ok", Results: []CheckResult{{CheckName: "iam-mfa-disabled", Severity: "HIGH", ResourceID: "alice"}}},
+			{Module: "docker", Status: "skipped", SkipReason: "no Dockerfile"},
+			{Module: "git", Status: "timed-out", Error: "timed out after 5m0s"},
+		},
+		Summary: Summary{TotalFindings: 1, High: 1, Score: 3, ModulesTimedOut: 1, ModulesSkipped: 1,
+			ModuleErrors: map[string]string{"git": "timed out after 5m0s"}},
+	}
+	if err := rep.RenderDocument(&buf, doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"aws Audit Results", "alice",
+		"[SKIPPED] no Dockerfile",
+		"[TIMED-OUT] timed out after 5m0s",
+		"=== Summary ===", "Score:", "1 timed out, 1 skipped",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTableReporter_Suppressed(t *testing.T) {
+	rep := NewTableReporter()
+	var buf bytes.Buffer
+	report := &Report{
+		Module: "aws",
+		Suppressed: []SuppressedResult{
+			{
+				CheckResult: CheckResult{CheckName: "s3-versioning-disabled", Severity: "MEDIUM", ResourceID: "app-logs"},
+				Reason:      "lifecycle rules",
+				Owner:       "platform",
+			},
+		},
+	}
+	if err := rep.Render(&buf, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"No issues found", "Suppressed (1):", "app-logs", "lifecycle rules", "platform"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTableReporter_Resolved(t *testing.T) {
+	rep := NewTableReporter()
+	var buf bytes.Buffer
+	report := &Report{
+		Module:   "aws",
+		Resolved: []CheckResult{{CheckName: "iam-mfa-disabled", Severity: "HIGH", ResourceID: "bob"}},
+	}
+	if err := rep.Render(&buf, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Resolved since baseline (1):", "iam-mfa-disabled", "bob"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTableReporter_Coverage(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Checks: []CheckRun{
+			{Check: "s3-public-bucket", Status: CheckPassed},
+			{Check: "iam-mfa-disabled", Status: CheckSkipped, SkipReason: "AccessDenied on iam:ListUsers"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewTableReporter().Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Coverage: 1 of 2 aws checks executed; 1 skipped: AccessDenied on iam:ListUsers",
+		"iam-mfa-disabled",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTableReporter_Passed(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Checks: []CheckRun{{
+			Check:     "ebs-unencrypted",
+			Status:    CheckPassed,
+			Evaluated: 2,
+			Passed: []Evidence{
+				{ResourceID: "vol-1", Message: `EBS volume "vol-1" is encrypted`},
+				{ResourceID: "vol-2", Message: `EBS volume "vol-2" is encrypted`},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewTableReporter().Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Passed (2):", "vol-1", `EBS volume "vol-2" is encrypted`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTableReporter_Compliance(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Compliance: &Compliance{
+			Framework: "cis-aws-1.5",
+			Name:      "CIS AWS Foundations Benchmark v1.5.0",
+			Passed:    1,
+			Failed:    1,
+			Controls: []ControlResult{
+				{ID: "1.10", Title: "MFA for console users", Status: ControlPass, Detail: "passed iam-mfa-disabled"},
+				{ID: "2.1.5", Title: "Block public access", Status: ControlFail, Detail: "1 finding(s) from s3-public-bucket"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewTableReporter().Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Compliance: CIS AWS Foundations Benchmark v1.5.0: 1 pass, 1 fail, 0 not covered",
+		"2.1.5",
+		"1 finding(s) from s3-public-bucket",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTableReporter_Profile(t *testing.T) {
+	doc := &Document{Metadata: Metadata{Command: "doctor", Version: "1.0.0", Profile: "prod"}}
+
+	var buf bytes.Buffer
+	if err := NewTableReporter().RenderDocument(&buf, doc); err != nil {
+		t.Fatalf("RenderDocument() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "(version 1.0.0, profile prod, generated") {
+		t.Errorf("header does not name the profile:\n%s", buf.String())
+	}
+}
+
+func TestTableReporter_Accounts(t *testing.T) {
+	report := &Report{
+		Module: "aws",
+		Results: []CheckResult{
+			{CheckName: "iam-mfa-disabled", Severity: "HIGH", ResourceID: "admin", Message: "no MFA", Account: "111111111111", AccountAlias: "prod"},
+		},
+	}
+	report.Accounts = CountAccounts([]AccountSummary{
+		{ID: "111111111111", Alias: "prod"},
+		{ID: "333333333333", Error: "cannot assume arn:aws:iam::333333333333:role/audit"},
+	}, report.Results)
+
+	var buf bytes.Buffer
+	if err := NewTableReporter().Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"ACCOUNT", "Accounts (2):", "cannot assume"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(out, "iam-mfa-disabled  prod") {
+		t.Errorf("finding does not name its account:\n%s", out)
+	}
+}
@@ -1,5 +1,10 @@
 package severity
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Level represents the severity of a finding.
 type Level string
 
@@ -10,6 +15,9 @@ const (
 	Critical Level = "CRITICAL"
 )
 
+// Never is a failure threshold that no finding reaches.
+const Never Level = "NEVER"
+
 // ExitCode returns the exit code corresponding to a severity level.
 func (l Level) ExitCode() int {
 	switch l {
@@ -67,3 +75,21 @@ func Highest(levels []Level) Level {
 	}
 	return highest
 }
+
+// ParseThreshold parses a failure threshold: a severity level or "never",
+// in any case.
+func ParseThreshold(s string) (Level, error) {
+	l := Level(strings.ToUpper(strings.TrimSpace(s)))
+	if l == Never || IsValid(string(l)) {
+		return l, nil
+	}
+	return "", fmt.Errorf("invalid threshold %q: must be one of low, medium, high, critical, never", s)
+}
+
+// Meets reports whether l is at or above threshold. Nothing meets Never.
+func (l Level) Meets(threshold Level) bool {
+	if threshold == Never || l.Weight() == 0 {
+		return false
+	}
+	return l.Weight() >= threshold.Weight()
+}
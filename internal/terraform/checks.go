@@ -1,24 +1,18 @@
 package terraform
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
 
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
 	"github.com/kaustuvbot/devopsctl/internal/severity"
 )
 
-// CheckResult represents the result of a terraform check.
-type CheckResult struct {
-	CheckName      string
-	Severity       severity.Level
-	ResourceID     string
-	Message        string
-	Recommendation string
-}
-
 // Checker performs terraform validation checks.
 type Checker struct {
 	workingDir string
@@ -42,60 +36,62 @@ func (c *Checker) ValidateDir() error {
 }
 
 // CheckFormat runs terraform fmt -check to verify formatting.
-func (c *Checker) CheckFormat() ([]CheckResult, error) {
+func (c *Checker) CheckFormat(ctx context.Context) ([]reporter.CheckResult, error) {
 	// Check if terraform binary exists
 	_, err := exec.LookPath("terraform")
 	if err != nil {
-		// terraform not found, skip this check
-		return []CheckResult{}, nil
+		return nil, check.Skip("terraform binary not found in PATH")
 	}
 
-	cmd := exec.Command("terraform", "fmt", "-check", "-recursive")
+	cmd := exec.CommandContext(ctx, "terraform", "fmt", "-check", "-recursive")
 	cmd.Dir = c.workingDir
 	err = cmd.Run()
 
-	var results []CheckResult
+	var results []reporter.CheckResult
 	if err != nil {
-		results = append(results, CheckResult{
+		results = append(results, reporter.CheckResult{
 			CheckName:      "terraform-fmt",
-			Severity:       severity.Medium,
+			Severity:       string(severity.Medium),
 			ResourceID:     c.workingDir,
 			Message:        "Terraform files are not properly formatted",
 			Recommendation: "Run 'terraform fmt' to fix formatting",
 		})
+	} else {
+		check.Pass(ctx, "terraform-fmt", c.workingDir, "Terraform files are properly formatted")
 	}
 	return results, nil
 }
 
 // CheckValidate runs terraform validate to check configuration validity.
-func (c *Checker) CheckValidate() ([]CheckResult, error) {
+func (c *Checker) CheckValidate(ctx context.Context) ([]reporter.CheckResult, error) {
 	// Check if terraform binary exists
 	_, err := exec.LookPath("terraform")
 	if err != nil {
-		// terraform not found, skip this check
-		return []CheckResult{}, nil
+		return nil, check.Skip("terraform binary not found in PATH")
 	}
 
-	cmd := exec.Command("terraform", "validate")
+	cmd := exec.CommandContext(ctx, "terraform", "validate")
 	cmd.Dir = c.workingDir
 	err = cmd.Run()
 
-	var results []CheckResult
+	var results []reporter.CheckResult
 	if err != nil {
-		results = append(results, CheckResult{
+		results = append(results, reporter.CheckResult{
 			CheckName:      "terraform-validate",
-			Severity:       severity.High,
+			Severity:       string(severity.High),
 			ResourceID:     c.workingDir,
 			Message:        "Terraform configuration is invalid",
 			Recommendation: "Fix terraform validation errors",
 		})
+	} else {
+		check.Pass(ctx, "terraform-validate", c.workingDir, "Terraform configuration is valid")
 	}
 	return results, nil
 }
 
 // CheckProviderVersions checks for unpinned provider versions in terraform files.
-func (c *Checker) CheckProviderVersions() ([]CheckResult, error) {
-	var results []CheckResult
+func (c *Checker) CheckProviderVersions(ctx context.Context) ([]reporter.CheckResult, error) {
+	var results []reporter.CheckResult
 
 	files, err := filepath.Glob(filepath.Join(c.workingDir, "*.tf"))
 	if err != nil {
@@ -121,13 +117,15 @@ func (c *Checker) CheckProviderVersions() ([]CheckResult, error) {
 		}
 
 		if hasRequiredProviders && !hasVersion {
-			results = append(results, CheckResult{
+			results = append(results, reporter.CheckResult{
 				CheckName:      "provider-version",
-				Severity:       severity.Medium,
+				Severity:       string(severity.Medium),
 				ResourceID:     file,
 				Message:        "Provider version constraint not found",
 				Recommendation: "Add version constraint to provider configuration",
 			})
+		} else if hasRequiredProviders {
+			check.Pass(ctx, "provider-version", file, "Provider version constraint found")
 		}
 	}
 
@@ -135,16 +133,19 @@ func (c *Checker) CheckProviderVersions() ([]CheckResult, error) {
 }
 
 // CheckCredentials detects hardcoded credentials in terraform files.
-func (c *Checker) CheckCredentials() ([]CheckResult, error) {
-	var results []CheckResult
-
-	// Patterns for detecting hardcoded credentials
-	patterns := map[string]string{
-		"aws_access_key": `AKIA[0-9A-Z]{16}`,
-		"aws_secret_key": `[A-Za-z0-9/+=]{40}`,
-		"password":       `password\s*=\s*"[^"]+"`,
-		"api_key":        `api_key\s*=\s*"[^"]+"`,
-		"secret":         `secret\s*=\s*"[^"]+"`,
+func (c *Checker) CheckCredentials(ctx context.Context) ([]reporter.CheckResult, error) {
+	var results []reporter.CheckResult
+
+	// Patterns for detecting hardcoded credentials, in reporting order
+	patterns := []struct {
+		credType string
+		pattern  string
+	}{
+		{"aws_access_key", `AKIA[0-9A-Z]{16}`},
+		{"aws_secret_key", `[A-Za-z0-9/+=]{40}`},
+		{"password", `password\s*=\s*"[^"]+"`},
+		{"api_key", `api_key\s*=\s*"[^"]+"`},
+		{"secret", `secret\s*=\s*"[^"]+"`},
 	}
 
 	files, err := filepath.Glob(filepath.Join(c.workingDir, "*.tf"))
@@ -160,18 +161,23 @@ func (c *Checker) CheckCredentials() ([]CheckResult, error) {
 
 		contentStr := string(content)
 
-		for credType, pattern := range patterns {
-			re := regexp.MustCompile(pattern)
+		found := false
+		for _, p := range patterns {
+			re := regexp.MustCompile(p.pattern)
 			if re.MatchString(contentStr) {
-				results = append(results, CheckResult{
+				results = append(results, reporter.CheckResult{
 					CheckName:      "hardcoded-credentials",
-					Severity:       severity.Critical,
+					Severity:       string(severity.Critical),
 					ResourceID:     file,
-					Message:        "Hardcoded " + credType + " detected",
+					Message:        "Hardcoded " + p.credType + " detected",
 					Recommendation: "Use environment variables or secret management instead",
 				})
+				found = true
 			}
 		}
+		if !found {
+			check.Pass(ctx, "hardcoded-credentials", file, "No hardcoded credentials detected")
+		}
 	}
 
 	return results, nil
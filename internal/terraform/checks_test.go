@@ -1,10 +1,14 @@
 package terraform
 
 import (
+	"context"
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
+	"github.com/kaustuvbot/devopsctl/internal/check"
 	"github.com/kaustuvbot/devopsctl/internal/severity"
 )
 
@@ -42,8 +46,8 @@ func TestValidateDir(t *testing.T) {
 
 func TestCheckFormat(t *testing.T) {
 	checker := NewChecker("../../testdata/terraform")
-	results, err := checker.CheckFormat()
-	if err != nil {
+	results, err := checker.CheckFormat(context.Background())
+	if err != nil && !isSkipped(err) {
 		t.Errorf("Expected no error, got %v", err)
 	}
 	// Results will vary based on whether terraform is installed and formatting is correct
@@ -53,8 +57,8 @@ func TestCheckFormat(t *testing.T) {
 
 func TestCheckValidate(t *testing.T) {
 	checker := NewChecker("../../testdata/terraform")
-	results, err := checker.CheckValidate()
-	if err != nil {
+	results, err := checker.CheckValidate(context.Background())
+	if err != nil && !isSkipped(err) {
 		t.Errorf("Expected no error, got %v", err)
 	}
 	// Results will vary based on whether terraform is installed and config is valid
@@ -132,7 +136,7 @@ func TestCheckProviderVersions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpDir := tt.setupDir(t)
 			checker := NewChecker(tmpDir)
-			results, err := checker.CheckProviderVersions()
+			results, err := checker.CheckProviderVersions(context.Background())
 			if err != nil {
 				t.Errorf("Expected no error, got %v", err)
 			}
@@ -146,7 +150,7 @@ func TestCheckProviderVersions(t *testing.T) {
 				if results[0].CheckName != "provider-version" {
 					t.Errorf("Expected check name 'provider-version', got %s", results[0].CheckName)
 				}
-				if results[0].Severity != severity.Medium {
+				if severity.Level(results[0].Severity) != severity.Medium {
 					t.Errorf("Expected severity MEDIUM, got %v", results[0].Severity)
 				}
 			}
@@ -226,7 +230,7 @@ func TestCheckCredentials(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpDir := tt.setupDir(t)
 			checker := NewChecker(tmpDir)
-			results, err := checker.CheckCredentials()
+			results, err := checker.CheckCredentials(context.Background())
 			if err != nil {
 				t.Errorf("Expected no error, got %v", err)
 			}
@@ -240,7 +244,7 @@ func TestCheckCredentials(t *testing.T) {
 				if results[0].CheckName != "hardcoded-credentials" {
 					t.Errorf("Expected check name 'hardcoded-credentials', got %s", results[0].CheckName)
 				}
-				if results[0].Severity != severity.Critical {
+				if severity.Level(results[0].Severity) != severity.Critical {
 					t.Errorf("Expected severity CRITICAL, got %v", results[0].Severity)
 				}
 				if results[0].Message == "" {
@@ -251,38 +255,11 @@ func TestCheckCredentials(t *testing.T) {
 	}
 }
 
-func TestCheckResultStructure(t *testing.T) {
-	// Verify that CheckResult has all required fields
-	result := CheckResult{
-		CheckName:      "test-check",
-		Severity:       severity.High,
-		ResourceID:     "test-resource",
-		Message:        "Test message",
-		Recommendation: "Test recommendation",
-	}
-
-	if result.CheckName == "" {
-		t.Error("CheckName should not be empty")
-	}
-	if result.Severity != severity.High {
-		t.Error("Severity should be set")
-	}
-	if result.ResourceID == "" {
-		t.Error("ResourceID should not be empty")
-	}
-	if result.Message == "" {
-		t.Error("Message should not be empty")
-	}
-	if result.Recommendation == "" {
-		t.Error("Recommendation should not be empty")
-	}
-}
-
 func TestCheckFormat_BinaryNotInstalled(t *testing.T) {
 	// Test with a valid terraform directory but no terraform binary in PATH
 	// The function should handle this gracefully
 	checker := NewChecker("../../testdata/terraform")
-	results, err := checker.CheckFormat()
+	results, err := checker.CheckFormat(context.Background())
 
 	// Should return without error, results depend on whether terraform is installed
 	// If terraform is not installed, we expect empty results or specific error handling
@@ -297,7 +274,7 @@ func TestCheckFormat_BinaryNotInstalled(t *testing.T) {
 func TestCheckValidate_BinaryNotInstalled(t *testing.T) {
 	// Similar to CheckFormat - test graceful handling of missing terraform
 	checker := NewChecker("../../testdata/terraform")
-	results, err := checker.CheckValidate()
+	results, err := checker.CheckValidate(context.Background())
 
 	// Should return without panic
 	_ = results
@@ -305,3 +282,22 @@ func TestCheckValidate_BinaryNotInstalled(t *testing.T) {
 		t.Logf("terraform binary check: %v", err)
 	}
 }
+
+func TestCheckFormat_SkippedWithoutBinary(t *testing.T) {
+	if _, err := exec.LookPath("terraform"); err == nil {
+		t.Skip("terraform is installed")
+	}
+	for name, run := range map[string]func() error{
+		"fmt":      func() error { _, err := NewChecker("../../testdata/terraform").CheckFormat(context.Background()); return err },
+		"validate": func() error { _, err := NewChecker("../../testdata/terraform").CheckValidate(context.Background()); return err },
+	} {
+		if err := run(); !isSkipped(err) {
+			t.Errorf("%s: expected skip without terraform binary, got %v", name, err)
+		}
+	}
+}
+
+func isSkipped(err error) bool {
+	var skip *check.SkipError
+	return errors.As(err, &skip)
+}
@@ -1,6 +1,12 @@
 package terraform
 
-import "fmt"
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/kaustuvbot/devopsctl/internal/check"
+	"github.com/kaustuvbot/devopsctl/internal/reporter"
+)
 
 // Runner orchestrates terraform validation checks.
 type Runner struct {
@@ -16,46 +22,29 @@ func NewRunner(workingDir string) *Runner {
 	}
 }
 
-// RunAllChecks runs all terraform checks and returns combined results.
-// Checks that fail are skipped, not fatal - returns partial results.
-func (r *Runner) RunAllChecks() ([]CheckResult, error) {
-	var allResults []CheckResult
-	var errs []string
-
-	// Run format check
-	formatResults, err := r.checker.CheckFormat()
-	if err != nil {
-		errs = append(errs, err.Error())
-	} else {
-		allResults = append(allResults, formatResults...)
-	}
-
-	// Run validate check
-	validateResults, err := r.checker.CheckValidate()
-	if err != nil {
-		errs = append(errs, err.Error())
-	} else {
-		allResults = append(allResults, validateResults...)
-	}
+// HasTerraformFiles reports whether the working directory contains any .tf files.
+func (r *Runner) HasTerraformFiles() bool {
+	files, err := filepath.Glob(filepath.Join(r.workingDir, "*.tf"))
+	return err == nil && len(files) > 0
+}
 
-	// Run provider version check
-	providerResults, err := r.checker.CheckProviderVersions()
-	if err != nil {
-		errs = append(errs, err.Error())
-	} else {
-		allResults = append(allResults, providerResults...)
+// Checks returns every terraform check.
+func Checks() []check.Check[*Checker] {
+	method := func(fn func(*Checker, context.Context) ([]reporter.CheckResult, error)) check.Func[*Checker] {
+		return func(ctx context.Context, c *Checker) ([]reporter.CheckResult, error) {
+			return fn(c, ctx)
+		}
 	}
-
-	// Run credentials check
-	credResults, err := r.checker.CheckCredentials()
-	if err != nil {
-		errs = append(errs, err.Error())
-	} else {
-		allResults = append(allResults, credResults...)
+	return []check.Check[*Checker]{
+		check.New("terraform-fmt", method((*Checker).CheckFormat)),
+		check.New("terraform-validate", method((*Checker).CheckValidate)),
+		check.New("provider-version", method((*Checker).CheckProviderVersions)),
+		check.New("hardcoded-credentials", method((*Checker).CheckCredentials)),
 	}
+}
 
-	if len(errs) > 0 {
-		return allResults, fmt.Errorf("some checks failed: %v", errs)
-	}
-	return allResults, nil
-}
\ No newline at end of file
+// RunAll runs all terraform checks and returns their outcomes.
+// Checks that fail are not fatal; their errors are reported in the outcomes.
+func (r *Runner) RunAll(ctx context.Context, opts check.Options) check.Outcomes {
+	return check.Run(ctx, r.checker, Checks(), opts)
+}
@@ -1,15 +1,46 @@
 package terraform
 
 import (
+	"context"
 	"testing"
+
+	"github.com/kaustuvbot/devopsctl/internal/check"
 )
 
 func TestTerraformRunner(t *testing.T) {
 	runner := NewRunner("../../testdata/terraform")
-	results, err := runner.RunAllChecks()
-	if err != nil {
+	outcomes := runner.RunAll(context.Background(), check.Options{})
+	if err := outcomes.Err(); err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
-	// Results will vary based on terraform installation and file contents
-	_ = results
-}
\ No newline at end of file
+	if len(outcomes) != len(Checks()) {
+		t.Errorf("Expected an outcome per check, got %d", len(outcomes))
+	}
+	// Findings will vary based on terraform installation and file contents
+}
+
+func TestTerraformRunnerDisabledCheck(t *testing.T) {
+	runner := NewRunner("../../testdata/terraform")
+	outcomes := runner.RunAll(context.Background(), check.Options{
+		Enabled: func(id string) bool { return id != "hardcoded-credentials" },
+	})
+	for _, o := range outcomes {
+		if o.ID == "hardcoded-credentials" && o.Status != check.StatusSkipped {
+			t.Errorf("disabled check status = %s, want skipped", o.Status)
+		}
+	}
+	for _, r := range outcomes.Findings() {
+		if r.CheckName == "hardcoded-credentials" {
+			t.Error("disabled check should not report findings")
+		}
+	}
+}
+
+func TestRunnerHasTerraformFiles(t *testing.T) {
+	if !NewRunner("../../testdata/terraform").HasTerraformFiles() {
+		t.Error("expected testdata directory to contain .tf files")
+	}
+	if NewRunner(t.TempDir()).HasTerraformFiles() {
+		t.Error("expected empty directory to contain no .tf files")
+	}
+}